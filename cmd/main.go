@@ -1,16 +1,24 @@
 package main
 
 import (
-	"bytes"
 	"flag"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"voidedtech.com/gxs/internal"
 	"voidedtech.com/stock"
 )
 
+const (
+	importCommand  = "import"
+	convertCommand = "convert"
+	infoCommand    = "info"
+	fmtCommand     = "fmt"
+	lintCommand    = "lint"
+)
+
 var (
 	version = "development"
 )
@@ -23,7 +31,202 @@ func stdin() []byte {
 	return b
 }
 
+func readInput(fileName string) []byte {
+	if fileName == "" {
+		return stdin()
+	}
+	raw, err := os.ReadFile(fileName)
+	if err != nil {
+		stock.Die("unable to read file", err)
+	}
+	return raw
+}
+
+func writeOutput(outFile string, b []byte) {
+	var write io.Writer
+	if len(outFile) == 0 {
+		write = os.Stdout
+	} else {
+		f, err := os.Create(outFile)
+		if err != nil {
+			stock.Die("unable to create output file", err)
+		}
+		defer f.Close()
+		write = f
+	}
+	if _, err := write.Write(b); err != nil {
+		stock.Die("failed to write output", err)
+	}
+}
+
+func importImage(args []string) {
+	set := flag.NewFlagSet(importCommand, flag.ExitOnError)
+	file := set.String("input", "", "image (png, jpeg, gif) to import (else stdin)")
+	out := set.String("output", "", "file to save the pattern (else stdout)")
+	width := set.Int("width", 50, "pattern width in stitches")
+	height := set.Int("height", 0, "pattern height in stitches (0 keeps the aspect ratio)")
+	colors := set.Int("colors", 16, "maximum number of floss colors")
+	if err := set.Parse(args); err != nil {
+		stock.Die("invalid arguments", err)
+	}
+	pattern, err := internal.Import(readInput(*file), *width, *height, *colors)
+	if err != nil {
+		stock.Die("unable to import image", err)
+	}
+	writeOutput(*out, pattern)
+}
+
+func convertBrand(args []string) {
+	set := flag.NewFlagSet(convertCommand, flag.ExitOnError)
+	file := set.String("input", "", "file to take as an input pattern (else stdin)")
+	out := set.String("output", "", "file to save the converted pattern (else stdout)")
+	brand := set.String("brand", "anchor", "floss brand to convert the palette(s) to")
+	if err := set.Parse(args); err != nil {
+		stock.Die("invalid arguments", err)
+	}
+	pattern, table, err := internal.Convert(readInput(*file), *brand)
+	if err != nil {
+		stock.Die("unable to convert pattern", err)
+	}
+	fmt.Fprint(os.Stderr, string(table))
+	writeOutput(*out, pattern)
+}
+
+func includePaths(set *flag.FlagSet, opts *internal.ParseOptions) {
+	set.Func("include-path", "directory to search for includes (repeatable)", func(s string) error {
+		opts.IncludePaths = append(opts.IncludePaths, s)
+		return nil
+	})
+}
+
+func parsePattern(fileName string, opts internal.ParseOptions) internal.Pattern {
+	opts.File = fileName
+	pattern, pErr := internal.ParseWith(readInput(fileName), opts)
+	dieParsing(pErr)
+	return pattern
+}
+
+func dieParsing(pErr *internal.ParserError) {
+	if pErr != nil && pErr.Error != nil {
+		errs := pErr.Errors()
+		for _, err := range errs {
+			switch {
+			case err.Position != nil || len(errs) > 1:
+				fmt.Fprintln(os.Stderr, err.Diagnostic())
+			case err.Backtrace != nil:
+				for _, line := range err.Backtrace {
+					fmt.Fprintln(os.Stderr, line)
+				}
+			}
+		}
+		if len(errs) > 1 {
+			stock.Die("unable to parse pattern", fmt.Errorf("%d errors", pErr.Found()))
+		}
+		stock.Die("unable to parse pattern", pErr.Error)
+	}
+}
+
+func patternInfo(args []string) {
+	set := flag.NewFlagSet(infoCommand, flag.ExitOnError)
+	file := set.String("input", "", "file to take as an input pattern (else stdin)")
+	option := &internal.Option{}
+	set.Func("option", "gxs options (e.g. fabric, margin)", func(s string) error {
+		return option.Set(s)
+	})
+	parse := internal.ParseOptions{}
+	includePaths(set, &parse)
+	if err := set.Parse(args); err != nil {
+		stock.Die("invalid arguments", err)
+	}
+	b, err := internal.Build(parsePattern(*file, parse), internal.InfoMode, option)
+	if err != nil {
+		stock.Die("failed to report info", err)
+	}
+	writeOutput("", b)
+}
+
+func formatPattern(args []string) {
+	set := flag.NewFlagSet(fmtCommand, flag.ExitOnError)
+	file := set.String("input", "", "file to take as an input pattern (else stdin)")
+	out := set.String("output", "", "file to save the formatted pattern (else stdout)")
+	write := set.Bool("w", false, "rewrite the input file in place")
+	diff := set.Bool("d", false, "display a diff (instead of the formatted pattern)")
+	if err := set.Parse(args); err != nil {
+		stock.Die("invalid arguments", err)
+	}
+	if *write && *file == "" {
+		stock.Die("invalid arguments", fmt.Errorf("-w requires an -input file"))
+	}
+	raw := readInput(*file)
+	formatted, err := internal.Format(raw)
+	if err != nil {
+		stock.Die("unable to format pattern", err)
+	}
+	if *diff {
+		name := *file
+		if name == "" {
+			name = "<stdin>"
+		}
+		writeOutput("", internal.Diff(name, raw, formatted))
+	}
+	if *write {
+		if string(raw) != string(formatted) {
+			writeOutput(*file, formatted)
+		}
+		return
+	}
+	if !*diff {
+		writeOutput(*out, formatted)
+	}
+}
+
+func lintPattern(args []string) {
+	set := flag.NewFlagSet(lintCommand, flag.ExitOnError)
+	file := set.String("input", "", "file to take as an input pattern (else stdin)")
+	var disabled []string
+	set.Func("disable", fmt.Sprintf("check to disable (repeatable): %s", strings.Join(internal.LintChecks(), ", ")), func(s string) error {
+		if err := internal.ValidateLintCheck(s); err != nil {
+			return err
+		}
+		disabled = append(disabled, s)
+		return nil
+	})
+	parse := internal.ParseOptions{}
+	includePaths(set, &parse)
+	if err := set.Parse(args); err != nil {
+		stock.Die("invalid arguments", err)
+	}
+	parse.File = *file
+	issues, pErr := internal.Lint(readInput(*file), parse, disabled)
+	dieParsing(pErr)
+	for _, issue := range issues {
+		fmt.Println(issue)
+	}
+	if len(issues) > 0 {
+		stock.Die("lint failed", fmt.Errorf("issues found: %d", len(issues)))
+	}
+}
+
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case importCommand:
+			importImage(os.Args[2:])
+			return
+		case convertCommand:
+			convertBrand(os.Args[2:])
+			return
+		case infoCommand:
+			patternInfo(os.Args[2:])
+			return
+		case fmtCommand:
+			formatPattern(os.Args[2:])
+			return
+		case lintCommand:
+			lintPattern(os.Args[2:])
+			return
+		}
+	}
 	file := flag.String("input", "", "file to take as an input pattern (else stdin)")
 	out := flag.String("output", "", "file to save output (else stdout)")
 	outMode := flag.String("format", internal.ASCIIMode, "output format")
@@ -32,43 +235,16 @@ func main() {
 	flag.Func("option", "gxs options", func(s string) error {
 		return option.Set(s)
 	})
+	parse := internal.ParseOptions{}
+	includePaths(flag.CommandLine, &parse)
 	flag.Parse()
 	if *showVers {
 		fmt.Printf("version: %s\n", version)
 		return
 	}
-	var b []byte
-	if fileName := *file; fileName == "" {
-		b = stdin()
-	} else {
-		raw, err := os.ReadFile(fileName)
-		if err != nil {
-			stock.Die("unable to read file", err)
-		}
-		b = raw
-	}
-	pattern, pErr := internal.Parse(b)
-	if pErr != nil && pErr.Error != nil {
-		if pErr.Backtrace != nil {
-			for _, line := range pErr.Backtrace {
-				fmt.Fprintln(os.Stderr, line)
-			}
-		}
-		stock.Die("unable to parse pattern", pErr.Error)
-	}
-	tmpl, err := internal.Build(pattern, *outMode, option)
+	tmpl, err := internal.Build(parsePattern(*file, parse), *outMode, option)
 	if err != nil {
 		stock.Die("failed to template", err)
 	}
-	var write io.Writer
-	outFile := *out
-	if len(outFile) == 0 {
-		write = os.Stdout
-	} else {
-		var b bytes.Buffer
-		write = &b
-	}
-	if _, err := write.Write(tmpl); err != nil {
-		stock.Die("failed to write output", err)
-	}
+	writeOutput(*out, tmpl)
 }
@@ -0,0 +1,173 @@
+package internal
+
+import (
+	"math"
+
+	"voidedtech.com/gxs/syntax"
+)
+
+const (
+	backstitchBlock = "backstitch"
+	// html cells are 10px with a 1px gap
+	htmlCellPitch = 11.0
+)
+
+type (
+	// gridPoint is an intersection (hole) of the grid, 0,0 is the top-left of the first cell.
+	gridPoint struct {
+		x int
+		y int
+	}
+	polyline struct {
+		color  string
+		floss  flossColor
+		points []gridPoint
+	}
+	// HTMLLine is a backstitch segment drawn over the HTML grid.
+	HTMLLine struct {
+		X1    float64
+		Y1    float64
+		X2    float64
+		Y2    float64
+		Color string
+	}
+)
+
+func toGridPoint(value *syntax.Point, offset patternOffset) (gridPoint, bool) {
+	if value.Bad {
+		return gridPoint{}, false
+	}
+	point := gridPoint{x: value.X + offset.x, y: value.Y + offset.y}
+	if point.x < 0 || point.y < 0 {
+		return gridPoint{}, false
+	}
+	return point, true
+}
+
+// parseBackstitch is every valid line of the block and the errors of any invalid lines.
+func parseBackstitch(block patternBlock, palette map[string]flossColor, offset patternOffset) ([]polyline, []*ParserError) {
+	var lines []polyline
+	var errs []*ParserError
+	for idx, entry := range block.entries {
+		line, ok := entry.(*syntax.Backstitch)
+		if !ok {
+			errs = append(errs, block.lineToError(idx, "invalid backstitch line"))
+			continue
+		}
+		result, err := parseBackstitchLine(line, palette, offset)
+		if err != "" {
+			errs = append(errs, block.lineToError(idx, err))
+			continue
+		}
+		lines = append(lines, result)
+	}
+	return lines, errs
+}
+
+func parseBackstitchLine(line *syntax.Backstitch, palette map[string]flossColor, offset patternOffset) (polyline, string) {
+	color, ok := palette[line.Symbol]
+	if !ok {
+		return polyline{}, "symbol unknown"
+	}
+	if color.resolved == noColor {
+		return polyline{}, "backstitch requires a color"
+	}
+	result := polyline{color: color.resolved, floss: color}
+	for _, value := range line.Points {
+		point, ok := toGridPoint(value, offset)
+		if !ok {
+			return polyline{}, "invalid backstitch point"
+		}
+		result.points = append(result.points, point)
+	}
+	if len(result.points) < 2 {
+		return polyline{}, "backstitch requires at least 2 points"
+	}
+	return result, ""
+}
+
+func (l polyline) length() float64 {
+	total := 0.0
+	for idx := 1; idx < len(l.points); idx++ {
+		total += math.Hypot(float64(l.points[idx].x-l.points[idx-1].x), float64(l.points[idx].y-l.points[idx-1].y))
+	}
+	return total
+}
+
+func (l polyline) segments(each func(from, to gridPoint)) {
+	for idx := 1; idx < len(l.points); idx++ {
+		each(l.points[idx-1], l.points[idx])
+	}
+}
+
+// PixelWidth is the width of the HTML grid (in pixels).
+func (o HTMLPattern) PixelWidth() float64 {
+	return float64(o.Width) * htmlCellPitch
+}
+
+// PixelHeight is the height of the HTML grid (in pixels).
+func (o HTMLPattern) PixelHeight() float64 {
+	return float64(o.Height) * htmlCellPitch
+}
+
+// Overlay indicates if anything is drawn over the HTML grid.
+func (o HTMLPattern) Overlay() bool {
+	return len(o.Lines) > 0 || len(o.Gridlines) > 0 || len(o.Markers) > 0
+}
+
+func (p Pattern) htmlLines() []HTMLLine {
+	var results []HTMLLine
+	// the first row/column are labels, intersections sit within the gap
+	position := func(v int) float64 {
+		return float64(v+1)*htmlCellPitch - 0.5
+	}
+	for _, l := range p.lines {
+		l.segments(func(from, to gridPoint) {
+			results = append(results, HTMLLine{X1: position(from.x), Y1: position(from.y), X2: position(to.x), Y2: position(to.y), Color: l.color})
+		})
+	}
+	return results
+}
+
+// asciiOverlay traces the backstitch lines over the ascii characters, every
+// cell is 2 characters wide/tall with intersections at the even positions.
+func (p Pattern) asciiOverlay() map[gridPoint]string {
+	overlay := make(map[gridPoint]string)
+	for _, l := range p.lines {
+		l.segments(func(from, to gridPoint) {
+			x1, y1 := 2*(from.x+1), 2*(from.y+1)
+			x2, y2 := 2*(to.x+1), 2*(to.y+1)
+			dx := x2 - x1
+			dy := y2 - y1
+			glyph := "\\"
+			switch {
+			case 2*abs(dy) < abs(dx):
+				glyph = "-"
+			case 2*abs(dx) < abs(dy):
+				glyph = "|"
+			case (dx > 0) != (dy > 0):
+				glyph = "/"
+			}
+			steps := abs(dx)
+			if abs(dy) > steps {
+				steps = abs(dy)
+			}
+			if steps == 0 {
+				return
+			}
+			for step := 0; step <= steps; step++ {
+				x := x1 + int(math.Round(float64(dx*step)/float64(steps)))
+				y := y1 + int(math.Round(float64(dy*step)/float64(steps)))
+				overlay[gridPoint{x: x, y: y}] = glyph
+			}
+		})
+	}
+	return overlay
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
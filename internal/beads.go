@@ -0,0 +1,33 @@
+package internal
+
+const (
+	millHillBrand = "millhill"
+)
+
+// millHillBeads are common Mill Hill glass seed beads, colors are approximate.
+func millHillBeads() []flossThread {
+	return []flossThread{
+		{code: "00020", name: "royalblue", rgb: "rgb(35, 60, 150)"},
+		{code: "00081", name: "jet", rgb: "rgb(20, 20, 20)"},
+		{code: "00123", name: "cream", rgb: "rgb(245, 235, 205)"},
+		{code: "00128", name: "yellowcreme", rgb: "rgb(250, 225, 120)"},
+		{code: "00145", name: "pink", rgb: "rgb(240, 170, 185)"},
+		{code: "00161", name: "crystal", rgb: "rgb(235, 240, 245)"},
+		{code: "00167", name: "christmasgreen", rgb: "rgb(20, 110, 50)"},
+		{code: "00283", name: "mercury", rgb: "rgb(170, 175, 180)"},
+		{code: "00332", name: "emerald", rgb: "rgb(0, 130, 90)"},
+		{code: "00358", name: "cobaltblue", rgb: "rgb(20, 50, 120)"},
+		{code: "00479", name: "white", rgb: "rgb(255, 255, 255)"},
+		{code: "00557", name: "gold", rgb: "rgb(200, 160, 60)"},
+		{code: "00968", name: "red", rgb: "rgb(190, 30, 45)"},
+		{code: "02010", name: "ice", rgb: "rgb(225, 235, 240)"},
+		{code: "02013", name: "redred", rgb: "rgb(170, 20, 35)"},
+	}
+}
+
+// beadCatalog are the bead brands, available alongside the floss catalog.
+func beadCatalog() map[string][]flossThread {
+	return map[string][]flossThread{
+		millHillBrand: millHillBeads(),
+	}
+}
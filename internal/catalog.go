@@ -0,0 +1,235 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"sort"
+	"strings"
+
+	"voidedtech.com/gxs/syntax"
+	"voidedtech.com/stock"
+)
+
+const (
+	dmcBrand       = "dmc"
+	anchorBrand    = "anchor"
+	cosmoBrand     = "cosmo"
+	brandSeparator = ":"
+)
+
+type (
+	flossCatalog struct {
+		names  map[string]flossThread
+		codes  map[string]flossThread
+		tables map[string][]flossEntry
+		beads  map[string]bool
+	}
+)
+
+// NewConvertError creates a new brand conversion error.
+func NewConvertError(message string) error {
+	return stock.NewBasicCategoryError("convert", message)
+}
+
+func catalog() map[string][]flossThread {
+	return map[string][]flossThread{
+		dmcBrand:    dmcThreads(),
+		anchorBrand: anchorThreads(),
+		cosmoBrand:  cosmoThreads(),
+	}
+}
+
+func newFlossCatalog() *flossCatalog {
+	c := &flossCatalog{
+		names:  make(map[string]flossThread),
+		codes:  make(map[string]flossThread),
+		tables: make(map[string][]flossEntry),
+		beads:  make(map[string]bool),
+	}
+	brands := catalog()
+	for brand, beads := range beadCatalog() {
+		brands[brand] = beads
+		c.beads[brand] = true
+	}
+	for brand, threads := range brands {
+		var table []flossEntry
+		for _, thread := range threads {
+			thread.brand = brand
+			rgb, err := parseColor(thread.rgb)
+			if err != nil {
+				continue
+			}
+			c.codes[thread.key()] = thread
+			// bare floss names have always been DMC names
+			if brand == dmcBrand {
+				c.names[thread.name] = thread
+			}
+			table = append(table, flossEntry{thread: thread, rgb: rgb, lab: toLab(rgb)})
+		}
+		c.tables[brand] = table
+	}
+	return c
+}
+
+func (t flossThread) key() string {
+	return fmt.Sprintf("%s%s%s", t.brand, brandSeparator, strings.ToUpper(t.code))
+}
+
+func (c *flossCatalog) nearest(brand string, rgb color.RGBA) (flossEntry, float64) {
+	table := c.tables[brand]
+	var all []int
+	for idx := range table {
+		all = append(all, idx)
+	}
+	idx, distance := nearestFloss(table, all, toLab(rgb))
+	return table[idx], distance
+}
+
+func (c *flossCatalog) resolveBlend(value string) (flossColor, error) {
+	floss := flossColor{input: value}
+	var r, g, b int
+	for _, part := range strings.Split(value, blendSeparator) {
+		if part == "" || part == noColor {
+			return floss, NewParsingError("invalid blend")
+		}
+		component, err := c.resolve(part)
+		if err != nil {
+			return floss, err
+		}
+		rgb, err := parseColor(component.resolved)
+		if err != nil {
+			return floss, err
+		}
+		r += int(rgb.R)
+		g += int(rgb.G)
+		b += int(rgb.B)
+		floss.blend = append(floss.blend, component)
+	}
+	// the strands in the needle mix into an average color
+	count := len(floss.blend)
+	floss.resolved = fmt.Sprintf("rgb(%d, %d, %d)", (r+count/2)/count, (g+count/2)/count, (b+count/2)/count)
+	return floss, nil
+}
+
+func (c *flossCatalog) resolve(value string) (flossColor, error) {
+	if strings.Contains(value, blendSeparator) {
+		return c.resolveBlend(value)
+	}
+	floss := flossColor{input: value, resolved: value}
+	if strings.HasPrefix(value, nearestPrefix) {
+		rgb, err := parseColor(strings.TrimPrefix(value, nearestPrefix))
+		if err != nil {
+			return floss, err
+		}
+		entry, distance := c.nearest(dmcBrand, rgb)
+		floss.thread = entry.thread
+		floss.nearest = true
+		floss.distance = distance
+	} else if parts := strings.SplitN(value, brandSeparator, 2); len(parts) == 2 && c.tables[parts[0]] != nil {
+		thread, ok := c.codes[flossThread{brand: parts[0], code: parts[1]}.key()]
+		if !ok {
+			return floss, NewParsingError(fmt.Sprintf("unknown %s floss", parts[0]))
+		}
+		floss.thread = thread
+	} else if thread, ok := c.names[value]; ok {
+		floss.thread = thread
+	}
+	if floss.thread.code != "" {
+		floss.resolved = floss.thread.rgb
+	}
+	return floss, nil
+}
+
+// convertAssignment is the color of a palette assignment converted to the brand.
+func (c *flossCatalog) convertAssignment(value, brand string, substitutions map[string]string) (string, error) {
+	floss, err := c.resolve(value)
+	if err != nil {
+		return "", err
+	}
+	if len(floss.blend) > 0 {
+		var converted []string
+		for _, component := range floss.blend {
+			to, err := c.convertColor(component.input, brand, substitutions)
+			if err != nil {
+				return "", err
+			}
+			converted = append(converted, to)
+		}
+		return strings.Join(converted, blendSeparator), nil
+	}
+	return c.convertColor(value, brand, substitutions)
+}
+
+func (c *flossCatalog) convertColor(value, brand string, substitutions map[string]string) (string, error) {
+	floss, err := c.resolve(value)
+	if err != nil {
+		return "", err
+	}
+	if floss.thread.brand == brand || c.beads[floss.thread.brand] {
+		return value, nil
+	}
+	rgb, err := parseColor(floss.resolved)
+	if err != nil {
+		if value != noColor {
+			substitutions[value] = fmt.Sprintf("%s => (unchanged, not a color)", value)
+		}
+		return value, nil
+	}
+	entry, distance := c.nearest(brand, rgb)
+	to := fmt.Sprintf("%s%s%s", brand, brandSeparator, entry.thread.code)
+	from := colorMap{input: value, thread: floss.thread, nearest: floss.nearest, distance: floss.distance}
+	substitutions[value] = fmt.Sprintf("%s => %s [%s] (delta-e: %.2f)", from.label(), to, entry.thread.name, distance)
+	return to, nil
+}
+
+// Convert rewrites every palette of a pattern to the nearest floss of another brand,
+// returning the new pattern and a substitution table.
+func Convert(b []byte, brand string) ([]byte, []byte, error) {
+	c := newFlossCatalog()
+	if _, ok := c.tables[brand]; !ok || c.beads[brand] {
+		var brands []string
+		for name := range c.tables {
+			if !c.beads[name] {
+				brands = append(brands, name)
+			}
+		}
+		sort.Strings(brands)
+		return nil, nil, NewConvertError(fmt.Sprintf("unknown brand: %s (brands: %s)", brand, strings.Join(brands, ", ")))
+	}
+	substitutions := make(map[string]string)
+	file, err := syntax.Parse(b)
+	if err != nil {
+		return nil, nil, NewConvertError(err.Error())
+	}
+	// colors are replaced in place so everything else (e.g. comments) is kept
+	lines := strings.Split(string(b), "\n")
+	for _, block := range file.Blocks() {
+		if block.Name != "palette" {
+			continue
+		}
+		for _, entry := range block.Entries() {
+			assignment, ok := entry.(*syntax.Assignment)
+			if !ok {
+				continue
+			}
+			converted, err := c.convertAssignment(assignment.Color, brand, substitutions)
+			if err != nil {
+				return nil, nil, err
+			}
+			line := lines[assignment.ColorPos.Line-1]
+			at := assignment.ColorPos.Column - 1
+			lines[assignment.ColorPos.Line-1] = line[:at] + converted + line[at+len(assignment.Color):]
+		}
+	}
+	var table []string
+	for _, substitution := range substitutions {
+		table = append(table, substitution)
+	}
+	sort.Strings(table)
+	var out bytes.Buffer
+	for _, line := range table {
+		out.WriteString(fmt.Sprintf("%s\n", line))
+	}
+	return []byte(strings.Join(lines, "\n")), out.Bytes(), nil
+}
@@ -0,0 +1,171 @@
+package internal_test
+
+import (
+	"strings"
+	"testing"
+
+	"voidedtech.com/gxs/internal"
+)
+
+func TestConvert(t *testing.T) {
+	b, table, err := internal.Convert([]byte(`palette => {
+	x => red
+	y => dmc:310 # black
+	z => NONE
+	w => notacolor
+}
+mode => {xstitch}
+pattern => {
+	xyzw
+}
+action => {commit}
+palette => {x => nearest:#010101}
+mode => {xstitch}
+pattern => {
+	x
+}
+action => {commit}
+`), "anchor")
+	if err != nil {
+		t.Error("valid conversion")
+	}
+	text := string(b)
+	for _, expect := range []string{"\tx => anchor:9046\n", "\ty => anchor:403 # black\n", "\tz => NONE\n", "\tw => notacolor\n", "palette => {x => anchor:403}\n"} {
+		if !strings.Contains(text, expect) {
+			t.Errorf("missing: %s", expect)
+		}
+	}
+	expect := `dmc:310 [black] => anchor:403 [black] (delta-e: 0.00)
+nearest:#010101 [nearest: black, dmc 310, delta-e: 0.16] => anchor:403 [black] (delta-e: 0.00)
+notacolor => (unchanged, not a color)
+red [dmc 321] => anchor:9046 [red] (delta-e: 0.00)
+`
+	if string(table) != expect {
+		t.Error("invalid substitution table")
+		t.Error(string(table))
+	}
+	if _, pErr := internal.Parse(b); pErr != nil {
+		t.Error("converted pattern should parse")
+	}
+	if _, _, err := internal.Convert(b, "other"); err == nil || err.Error() != "convert: unknown brand: other (brands: anchor, cosmo, dmc)" {
+		t.Error("unknown brand")
+	}
+}
+
+func TestConvertAnchor(t *testing.T) {
+	_, table, err := internal.Convert([]byte(`palette => {
+	a => dmc:816
+	b => dmc:3708
+	c => dmc:561
+	d => dmc:830
+	e => dmc:3041
+}
+`), "anchor")
+	if err != nil {
+		t.Fatal("valid conversion")
+	}
+	for _, expect := range []string{"=> anchor:22 [garnet]", "=> anchor:31 [melonlight]", "=> anchor:212 [celadongreenvd]", "=> anchor:277 [goldenolivedk]", "=> anchor:871 [antiqueviolet"} {
+		if !strings.Contains(string(table), expect) {
+			t.Errorf("missing: %s", expect)
+		}
+	}
+	if strings.Count(string(table), "(delta-e: 0.00)") != 5 {
+		t.Errorf("anchor equivalents should match exactly:\n%s", table)
+	}
+}
+
+func TestCosmo(t *testing.T) {
+	p, err := internal.Parse([]byte(`palette => {
+	x => cosmo:600
+}
+mode => {xstitch}
+pattern => {x}
+action => {commit}`))
+	if err != nil {
+		t.Fatalf("is valid: %v", err.Error)
+	}
+	b, bErr := internal.Build(p, internal.ASCIIMode, &internal.Option{})
+	if bErr != nil || !strings.Contains(string(b), "color: a => cosmo:600 [black] (count: 1)") {
+		t.Errorf("invalid legend: %s", string(b))
+	}
+	converted, table, cErr := internal.Convert([]byte("palette => {\n\tx => dmc:321\n\ty => nearest:#fefefe\n}\n"), "cosmo")
+	if cErr != nil {
+		t.Fatal("valid conversion")
+	}
+	for _, expect := range []string{"\tx => cosmo:241\n", "\ty => cosmo:100\n"} {
+		if !strings.Contains(string(converted), expect) {
+			t.Errorf("missing: %s", expect)
+		}
+	}
+	if !strings.Contains(string(table), "dmc:321 [red] => cosmo:241 [red] (delta-e: 0.00)") {
+		t.Errorf("invalid substitution table: %s", string(table))
+	}
+	if _, err := internal.Parse([]byte("palette => {x => cosmo:1}\n")); err == nil || err.Error.Error() != "parsing: unknown cosmo floss" {
+		t.Error("unknown cosmo floss")
+	}
+}
+
+func TestBrandPalette(t *testing.T) {
+	p, err := internal.Parse([]byte(`palette => {
+	x => anchor:403
+}
+mode => {xstitch}
+pattern => {x}
+action => {commit}`))
+	if err != nil {
+		t.Error("is valid")
+	}
+	b, bErr := internal.Build(p, "ascii", &internal.Option{})
+	if bErr != nil || !strings.Contains(string(b), "color: a => anchor:403 [black] (count: 1)") {
+		t.Error("invalid legend")
+	}
+	_, err = internal.Parse([]byte(`palette => {
+	x => anchor:99999
+}`))
+	if err == nil || err.Error.Error() != "parsing: unknown anchor floss" {
+		t.Error("wrong error")
+	}
+}
+
+func TestBeads(t *testing.T) {
+	p, err := internal.Parse([]byte(`palette => {
+	x => red
+	b => millhill:00123
+	- => NONE
+}
+mode => {xstitch}
+pattern => {x}
+action => {commit}
+mode => {bead}
+pattern => {
+	--
+	-b
+}
+action => {commit}
+`))
+	if err != nil {
+		t.Errorf("valid palette: %v", err.Error)
+	}
+	b, bErr := internal.Build(p, internal.HTMLMode, &internal.Option{})
+	if bErr != nil || !strings.Contains(string(b), "bead: millhill:00123 [cream] (count 1)") {
+		t.Error("invalid bead legend")
+	}
+	b, bErr = internal.Build(p, internal.SummaryMode, &internal.Option{})
+	if bErr != nil || strings.Contains(string(b), "millhill") {
+		t.Error("beads do not use thread")
+	}
+	converted, _, cErr := internal.Convert([]byte("palette => {b => millhill:00123}\n"), "anchor")
+	if cErr != nil || string(converted) != "palette => {b => millhill:00123}\n" {
+		t.Error("beads are not converted")
+	}
+	if _, _, err := internal.Convert(converted, "millhill"); err == nil || err.Error() != "convert: unknown brand: millhill (brands: anchor, cosmo, dmc)" {
+		t.Error("can not convert to beads")
+	}
+	_, err = internal.Parse([]byte("palette => {\nx => red\nb => millhill:00123\n}\nmode => {bead}\npattern => {\nbx\n}\naction => {commit}\n"))
+	if err == nil || len(err.Errors()) != 1 || err.Diagnostic() != "<stdin>:7:2: parsing: bead mode requires a bead: red\nbx\n ^" {
+		t.Errorf("beads are from the bead catalog: %v", err)
+	}
+	if _, err := internal.Parse([]byte("palette => {b => millhill:1}\n")); err == nil || err.Error.Error() != "parsing: unknown millhill floss" {
+		t.Error("unknown bead")
+	}
+}
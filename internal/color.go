@@ -0,0 +1,347 @@
+package internal
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"voidedtech.com/stock"
+)
+
+type (
+	labColor struct {
+		l float64
+		a float64
+		b float64
+	}
+	flossEntry struct {
+		thread flossThread
+		rgb    color.RGBA
+		lab    labColor
+	}
+)
+
+func namedColors() map[string]color.RGBA {
+	named := make(map[string]color.RGBA)
+	add := func(name string, r, g, b uint8) {
+		named[name] = color.RGBA{R: r, G: g, B: b, A: 255}
+	}
+	add("aliceblue", 240, 248, 255)
+	add("antiquewhite", 250, 235, 215)
+	add("aqua", 0, 255, 255)
+	add("aquamarine", 127, 255, 212)
+	add("azure", 240, 255, 255)
+	add("beige", 245, 245, 220)
+	add("bisque", 255, 228, 196)
+	add("black", 0, 0, 0)
+	add("blanchedalmond", 255, 235, 205)
+	add("blue", 0, 0, 255)
+	add("blueviolet", 138, 43, 226)
+	add("brown", 165, 42, 42)
+	add("burlywood", 222, 184, 135)
+	add("cadetblue", 95, 158, 160)
+	add("chartreuse", 127, 255, 0)
+	add("chocolate", 210, 105, 30)
+	add("coral", 255, 127, 80)
+	add("cornflowerblue", 100, 149, 237)
+	add("cornsilk", 255, 248, 220)
+	add("crimson", 220, 20, 60)
+	add("cyan", 0, 255, 255)
+	add("darkblue", 0, 0, 139)
+	add("darkcyan", 0, 139, 139)
+	add("darkgoldenrod", 184, 134, 11)
+	add("darkgray", 169, 169, 169)
+	add("darkgreen", 0, 100, 0)
+	add("darkgrey", 169, 169, 169)
+	add("darkkhaki", 189, 183, 107)
+	add("darkmagenta", 139, 0, 139)
+	add("darkolivegreen", 85, 107, 47)
+	add("darkorange", 255, 140, 0)
+	add("darkorchid", 153, 50, 204)
+	add("darkred", 139, 0, 0)
+	add("darksalmon", 233, 150, 122)
+	add("darkseagreen", 143, 188, 143)
+	add("darkslateblue", 72, 61, 139)
+	add("darkslategray", 47, 79, 79)
+	add("darkslategrey", 47, 79, 79)
+	add("darkturquoise", 0, 206, 209)
+	add("darkviolet", 148, 0, 211)
+	add("deeppink", 255, 20, 147)
+	add("deepskyblue", 0, 191, 255)
+	add("dimgray", 105, 105, 105)
+	add("dimgrey", 105, 105, 105)
+	add("dodgerblue", 30, 144, 255)
+	add("firebrick", 178, 34, 34)
+	add("floralwhite", 255, 250, 240)
+	add("forestgreen", 34, 139, 34)
+	add("fuchsia", 255, 0, 255)
+	add("gainsboro", 220, 220, 220)
+	add("ghostwhite", 248, 248, 255)
+	add("gold", 255, 215, 0)
+	add("goldenrod", 218, 165, 32)
+	add("gray", 128, 128, 128)
+	add("green", 0, 128, 0)
+	add("greenyellow", 173, 255, 47)
+	add("grey", 128, 128, 128)
+	add("honeydew", 240, 255, 240)
+	add("hotpink", 255, 105, 180)
+	add("indianred", 205, 92, 92)
+	add("indigo", 75, 0, 130)
+	add("ivory", 255, 255, 240)
+	add("khaki", 240, 230, 140)
+	add("lavender", 230, 230, 250)
+	add("lavenderblush", 255, 240, 245)
+	add("lawngreen", 124, 252, 0)
+	add("lemonchiffon", 255, 250, 205)
+	add("lightblue", 173, 216, 230)
+	add("lightcoral", 240, 128, 128)
+	add("lightcyan", 224, 255, 255)
+	add("lightgoldenrodyellow", 250, 250, 210)
+	add("lightgray", 211, 211, 211)
+	add("lightgreen", 144, 238, 144)
+	add("lightgrey", 211, 211, 211)
+	add("lightpink", 255, 182, 193)
+	add("lightsalmon", 255, 160, 122)
+	add("lightseagreen", 32, 178, 170)
+	add("lightskyblue", 135, 206, 250)
+	add("lightslategray", 119, 136, 153)
+	add("lightslategrey", 119, 136, 153)
+	add("lightsteelblue", 176, 196, 222)
+	add("lightyellow", 255, 255, 224)
+	add("lime", 0, 255, 0)
+	add("limegreen", 50, 205, 50)
+	add("linen", 250, 240, 230)
+	add("magenta", 255, 0, 255)
+	add("maroon", 128, 0, 0)
+	add("mediumaquamarine", 102, 205, 170)
+	add("mediumblue", 0, 0, 205)
+	add("mediumorchid", 186, 85, 211)
+	add("mediumpurple", 147, 112, 219)
+	add("mediumseagreen", 60, 179, 113)
+	add("mediumslateblue", 123, 104, 238)
+	add("mediumspringgreen", 0, 250, 154)
+	add("mediumturquoise", 72, 209, 204)
+	add("mediumvioletred", 199, 21, 133)
+	add("midnightblue", 25, 25, 112)
+	add("mintcream", 245, 255, 250)
+	add("mistyrose", 255, 228, 225)
+	add("moccasin", 255, 228, 181)
+	add("navajowhite", 255, 222, 173)
+	add("navy", 0, 0, 128)
+	add("oldlace", 253, 245, 230)
+	add("olive", 128, 128, 0)
+	add("olivedrab", 107, 142, 35)
+	add("orange", 255, 165, 0)
+	add("orangered", 255, 69, 0)
+	add("orchid", 218, 112, 214)
+	add("palegoldenrod", 238, 232, 170)
+	add("palegreen", 152, 251, 152)
+	add("paleturquoise", 175, 238, 238)
+	add("palevioletred", 219, 112, 147)
+	add("papayawhip", 255, 239, 213)
+	add("peachpuff", 255, 218, 185)
+	add("peru", 205, 133, 63)
+	add("pink", 255, 192, 203)
+	add("plum", 221, 160, 221)
+	add("powderblue", 176, 224, 230)
+	add("purple", 128, 0, 128)
+	add("rebeccapurple", 102, 51, 153)
+	add("red", 255, 0, 0)
+	add("rosybrown", 188, 143, 143)
+	add("royalblue", 65, 105, 225)
+	add("saddlebrown", 139, 69, 19)
+	add("salmon", 250, 128, 114)
+	add("sandybrown", 244, 164, 96)
+	add("seagreen", 46, 139, 87)
+	add("seashell", 255, 245, 238)
+	add("sienna", 160, 82, 45)
+	add("silver", 192, 192, 192)
+	add("skyblue", 135, 206, 235)
+	add("slateblue", 106, 90, 205)
+	add("slategray", 112, 128, 144)
+	add("slategrey", 112, 128, 144)
+	add("snow", 255, 250, 250)
+	add("springgreen", 0, 255, 127)
+	add("steelblue", 70, 130, 180)
+	add("tan", 210, 180, 140)
+	add("teal", 0, 128, 128)
+	add("thistle", 216, 191, 216)
+	add("tomato", 255, 99, 71)
+	add("turquoise", 64, 224, 208)
+	add("violet", 238, 130, 238)
+	add("wheat", 245, 222, 179)
+	add("white", 255, 255, 255)
+	add("whitesmoke", 245, 245, 245)
+	add("yellow", 255, 255, 0)
+	add("yellowgreen", 154, 205, 50)
+	return named
+}
+
+// NewColorError creates a new color-parsing error.
+func NewColorError(message string) error {
+	return stock.NewBasicCategoryError("color", message)
+}
+
+func parseColor(value string) (color.RGBA, error) {
+	raw := strings.ToLower(strings.TrimSpace(value))
+	if strings.HasPrefix(raw, "#") {
+		hex := raw[1:]
+		if len(hex) == 3 {
+			hex = fmt.Sprintf("%c%c%c%c%c%c", hex[0], hex[0], hex[1], hex[1], hex[2], hex[2])
+		}
+		if len(hex) != 6 {
+			return color.RGBA{}, NewColorError(fmt.Sprintf("invalid hex color: %s", value))
+		}
+		val, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return color.RGBA{}, NewColorError(fmt.Sprintf("invalid hex color: %s", value))
+		}
+		return color.RGBA{R: uint8(val >> 16), G: uint8(val >> 8), B: uint8(val), A: 255}, nil
+	}
+	if strings.HasPrefix(raw, "rgb(") && strings.HasSuffix(raw, ")") {
+		parts := strings.Split(raw[4:len(raw)-1], ",")
+		if len(parts) != 3 {
+			return color.RGBA{}, NewColorError(fmt.Sprintf("invalid rgb color: %s", value))
+		}
+		var channels []uint8
+		for _, part := range parts {
+			val, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil || val < 0 || val > 255 {
+				return color.RGBA{}, NewColorError(fmt.Sprintf("invalid rgb color: %s", value))
+			}
+			channels = append(channels, uint8(val))
+		}
+		return color.RGBA{R: channels[0], G: channels[1], B: channels[2], A: 255}, nil
+	}
+	if named, ok := namedColors()[raw]; ok {
+		return named, nil
+	}
+	return color.RGBA{}, NewColorError(fmt.Sprintf("unknown color: %s", value))
+}
+
+func (p Pattern) resolveColors() (map[string]color.RGBA, error) {
+	resolved := make(map[string]color.RGBA)
+	for _, mapped := range p.colors {
+		c, err := parseColor(mapped.output)
+		if err != nil {
+			return nil, err
+		}
+		resolved[mapped.output] = c
+	}
+	return resolved, nil
+}
+
+func nearestFloss(table []flossEntry, candidates []int, lab labColor) (int, float64) {
+	best := -1
+	bestDistance := 0.0
+	for _, idx := range candidates {
+		distance := deltaE(table[idx].lab, lab)
+		if best < 0 || distance < bestDistance {
+			best = idx
+			bestDistance = distance
+		}
+	}
+	return best, bestDistance
+}
+
+func linearChannel(c uint8) float64 {
+	v := float64(c) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func labChannel(t float64) float64 {
+	if t > 216.0/24389.0 {
+		return math.Cbrt(t)
+	}
+	return (24389.0/27.0*t + 16) / 116
+}
+
+// toLab converts an sRGB color to CIELAB (D65 white point).
+func toLab(c color.RGBA) labColor {
+	r := linearChannel(c.R)
+	g := linearChannel(c.G)
+	b := linearChannel(c.B)
+	x := (0.4124564*r + 0.3575761*g + 0.1804375*b) / 0.95047
+	y := 0.2126729*r + 0.7151522*g + 0.0721750*b
+	z := (0.0193339*r + 0.1191920*g + 0.9503041*b) / 1.08883
+	fx := labChannel(x)
+	fy := labChannel(y)
+	fz := labChannel(z)
+	return labColor{l: 116*fy - 16, a: 500 * (fx - fy), b: 200 * (fy - fz)}
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+// deltaE is the CIEDE2000 color difference between two CIELAB colors.
+func deltaE(x, y labColor) float64 {
+	c1 := math.Hypot(x.a, x.b)
+	c2 := math.Hypot(y.a, y.b)
+	cMean := (c1 + c2) / 2
+	c7 := math.Pow(cMean, 7)
+	g := 0.5 * (1 - math.Sqrt(c7/(c7+math.Pow(25, 7))))
+	a1 := x.a * (1 + g)
+	a2 := y.a * (1 + g)
+	c1p := math.Hypot(a1, x.b)
+	c2p := math.Hypot(a2, y.b)
+	hue := func(b, a float64) float64 {
+		if a == 0 && b == 0 {
+			return 0
+		}
+		h := degrees(math.Atan2(b, a))
+		if h < 0 {
+			h += 360
+		}
+		return h
+	}
+	h1 := hue(x.b, a1)
+	h2 := hue(y.b, a2)
+	dL := y.l - x.l
+	dC := c2p - c1p
+	dh := 0.0
+	if c1p*c2p != 0 {
+		dh = h2 - h1
+		if dh > 180 {
+			dh -= 360
+		} else if dh < -180 {
+			dh += 360
+		}
+	}
+	dH := 2 * math.Sqrt(c1p*c2p) * math.Sin(radians(dh/2))
+	lMean := (x.l + y.l) / 2
+	cpMean := (c1p + c2p) / 2
+	hMean := h1 + h2
+	if c1p*c2p != 0 {
+		if math.Abs(h1-h2) > 180 {
+			if h1+h2 < 360 {
+				hMean += 360
+			} else {
+				hMean -= 360
+			}
+		}
+		hMean /= 2
+	}
+	t := 1 - 0.17*math.Cos(radians(hMean-30)) +
+		0.24*math.Cos(radians(2*hMean)) +
+		0.32*math.Cos(radians(3*hMean+6)) -
+		0.20*math.Cos(radians(4*hMean-63))
+	dTheta := 30 * math.Exp(-math.Pow((hMean-275)/25, 2))
+	cp7 := math.Pow(cpMean, 7)
+	rc := 2 * math.Sqrt(cp7/(cp7+math.Pow(25, 7)))
+	l50 := (lMean - 50) * (lMean - 50)
+	sl := 1 + 0.015*l50/math.Sqrt(20+l50)
+	sc := 1 + 0.045*cpMean
+	sh := 1 + 0.015*cpMean*t
+	rt := -math.Sin(radians(2*dTheta)) * rc
+	return math.Sqrt(math.Pow(dL/sl, 2) + math.Pow(dC/sc, 2) + math.Pow(dH/sh, 2) + rt*(dC/sc)*(dH/sh))
+}
@@ -0,0 +1,198 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"voidedtech.com/gxs/syntax"
+)
+
+const (
+	// defaultMaxErrors matches the number of errors a compiler usually reports
+	defaultMaxErrors = 10
+)
+
+var (
+	errTooManyErrors = NewParsingError("too many errors")
+)
+
+type (
+	// Position is a location within a pattern (or included) file.
+	Position struct {
+		File   string
+		Line   int
+		Column int
+		// Source is the (raw) line at the position.
+		Source string
+	}
+	// parserErrors collects errors (up to a limit) so parsing can continue past an error.
+	parserErrors struct {
+		errors []*ParserError
+		limit  int
+		// truncated is set once an error arrives past the limit.
+		truncated bool
+		// files are the files in the order read (errors are ordered by file).
+		files map[string]int
+	}
+	// sourceFile is the (raw) lines of a pattern (or included) file.
+	sourceFile struct {
+		path  string
+		lines []string
+	}
+)
+
+func toSourceFile(b []byte, file string) sourceFile {
+	return sourceFile{path: file, lines: strings.Split(string(b), "\n")}
+}
+
+// position is where a syntax position is within the file.
+func (s sourceFile) position(pos syntax.Pos) Position {
+	source := ""
+	if pos.Line > 0 && pos.Line <= len(s.lines) {
+		source = s.lines[pos.Line-1]
+	}
+	return Position{File: s.path, Line: pos.Line, Column: pos.Column, Source: source}
+}
+
+// toPatternBlock is a block (and its entries) positioned within the file.
+func (s sourceFile) toPatternBlock(block *syntax.Block) patternBlock {
+	result := patternBlock{mode: block.Name, start: s.position(block.Start)}
+	for _, entry := range block.Entries() {
+		result.lines = append(result.lines, entry.String())
+		result.positions = append(result.positions, s.position(entry.Pos()))
+		result.entries = append(result.entries, entry)
+	}
+	return result
+}
+
+// String is the compiler-style file:line:col of the position.
+func (p Position) String() string {
+	file := p.File
+	if file == "" {
+		file = stdinName
+	}
+	return fmt.Sprintf("%s:%d:%d", file, p.Line, p.Column)
+}
+
+// excerpt is the source line with a caret under the column.
+func (p Position) excerpt() string {
+	var caret strings.Builder
+	for idx, r := range p.Source {
+		if idx >= p.Column-1 {
+			break
+		}
+		// keep tabs so the caret lines up with the source
+		if r == '\t' {
+			caret.WriteRune(r)
+		} else {
+			caret.WriteRune(' ')
+		}
+	}
+	caret.WriteString("^")
+	return fmt.Sprintf("%s\n%s", p.Source, caret.String())
+}
+
+// Diagnostic is the error as a compiler-style message (file:line:col: message) with a caret excerpt.
+func (e *ParserError) Diagnostic() string {
+	if e.Position == nil {
+		return e.Error.Error()
+	}
+	return fmt.Sprintf("%s: %s\n%s", e.Position, e.Error.Error(), e.Position.excerpt())
+}
+
+func (b patternBlock) lineError(idx int, err error) *ParserError {
+	result := &ParserError{Error: err, Backtrace: b.lines}
+	if idx < len(b.positions) {
+		position := b.positions[idx]
+		result.Position = &position
+	}
+	return result
+}
+
+func (b patternBlock) lineToError(idx int, message string) *ParserError {
+	return b.lineError(idx, NewParsingError(message))
+}
+
+func (o ParseOptions) errors() *parserErrors {
+	limit := o.MaxErrors
+	if limit < 1 {
+		limit = defaultMaxErrors
+	}
+	return &parserErrors{limit: limit, files: make(map[string]int)}
+}
+
+// add records an error, it is true once an error arrives past the limit (and parsing should stop).
+func (e *parserErrors) add(err *ParserError) bool {
+	if len(e.errors) >= e.limit {
+		e.truncated = true
+	}
+	if e.truncated {
+		return true
+	}
+	e.errors = append(e.errors, err)
+	return false
+}
+
+func (e *parserErrors) full() bool {
+	return e.truncated
+}
+
+// read records a file (once) in the order files are read.
+func (e *parserErrors) read(file string) {
+	if _, ok := e.files[file]; !ok {
+		e.files[file] = len(e.files)
+	}
+}
+
+// result is the first error with any others (in source order, those without a position last) attached.
+func (e *parserErrors) result() *ParserError {
+	if len(e.errors) == 0 {
+		return nil
+	}
+	errors := append([]*ParserError{}, e.errors...)
+	sort.SliceStable(errors, func(i, j int) bool {
+		a, b := errors[i].Position, errors[j].Position
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		if a.File != b.File {
+			return e.files[a.File] < e.files[b.File]
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	if e.truncated {
+		errors = append(errors, &ParserError{Error: errTooManyErrors})
+	}
+	first := errors[0]
+	first.More = errors[1:]
+	return first
+}
+
+// Found is the number of errors found (not counting a too many errors marker).
+func (e *ParserError) Found() int {
+	count := 0
+	for _, err := range e.Errors() {
+		if err.Error != errTooManyErrors {
+			count++
+		}
+	}
+	return count
+}
+
+// Errors are all errors found (in source order).
+func (e *ParserError) Errors() []*ParserError {
+	return append([]*ParserError{e}, e.More...)
+}
+
+// Diagnostics are the diagnostics of all errors found.
+func (e *ParserError) Diagnostics() []string {
+	var results []string
+	for _, err := range e.Errors() {
+		results = append(results, err.Diagnostic())
+	}
+	return results
+}
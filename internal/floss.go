@@ -1,460 +1,536 @@
 package internal
 
-func colors() map[string]string {
-	floss := make(map[string]string)
-	floss["dustyroseultvydk"] = "rgb(171, 2, 73)"
-	floss["dustyrosevrylt"] = "rgb(240, 206, 212)"
-	floss["shellpinkmedlight"] = "rgb(226, 160, 153)"
-	floss["violetverylight"] = "rgb(230, 204, 217)"
-	floss["grapeverydark"] = "rgb(87, 36, 51)"
-	floss["bluevioletmeddark"] = "rgb(152, 145, 182)"
-	floss["bluevioletmedlt"] = "rgb(163, 174, 209)"
-	floss["cornflowerbluevylt"] = "rgb(187, 195, 217)"
-	floss["cornflowerblumvd"] = "rgb(76, 82, 110)"
-	floss["bluegraylight"] = "rgb(199, 202, 215)"
-	floss["bluegraymedium"] = "rgb(153, 159, 183)"
-	floss["bluegray"] = "rgb(120, 128, 164)"
-	floss["blueultraverylight"] = "rgb(219, 236, 245)"
-	floss["celadongreenmd"] = "rgb(77, 131, 97)"
-	floss["forestgreenlt"] = "rgb(200, 216, 184)"
-	floss["mossgreenvylt"] = "rgb(239, 244, 164)"
-	floss["mossgreenmdlt"] = "rgb(192, 200, 64)"
-	floss["yellowbeigevdk"] = "rgb(167, 124, 73)"
-	floss["pewterverylight"] = "rgb(209, 209, 209)"
-	floss["pewterlight"] = "rgb(132, 132, 132)"
-	floss["lavenderverydark"] = "rgb(131, 91, 139)"
-	floss["lavenderdark"] = "rgb(163, 123, 167)"
-	floss["lavendermedium"] = "rgb(195, 159, 195)"
-	floss["lavenderlight"] = "rgb(227, 203, 227)"
-	floss["shellpinkvydk"] = "rgb(136, 62, 67)"
-	floss["shellpinklight"] = "rgb(204, 132, 124)"
-	floss["shellpinkverylight"] = "rgb(235, 183, 175)"
-	floss["shellpinkultvylt"] = "rgb(255, 223, 213)"
-	floss["mahoganyvydk"] = "rgb(111, 47, 0)"
-	floss["mahoganymed"] = "rgb(179, 95, 43)"
-	floss["khakigreendk"] = "rgb(137, 138, 88)"
-	floss["khakigreenmd"] = "rgb(166, 167, 93)"
-	floss["khakigreenlt"] = "rgb(185, 185, 130)"
-	floss["browngrayvydk"] = "rgb(79, 75, 65)"
-	floss["browngraymed"] = "rgb(142, 144, 120)"
-	floss["browngraylight"] = "rgb(177, 170, 151)"
-	floss["browngrayvylt"] = "rgb(235, 234, 231)"
-	floss["mochabrownvydk"] = "rgb(75, 60, 42)"
-	floss["mochabrownmed"] = "rgb(179, 159, 139)"
-	floss["mochabrownvylt"] = "rgb(227, 216, 204)"
-	floss["redmedium"] = "rgb(183, 31, 51)"
-	floss["antiquevioletmedium"] = "rgb(149, 111, 124)"
-	floss["antiquevioletlight"] = "rgb(183, 157, 167)"
-	floss["yellowbeigedk"] = "rgb(188, 150, 106)"
-	floss["yellowbeigemd"] = "rgb(216, 188, 154)"
-	floss["yellowbeigelt"] = "rgb(231, 214, 193)"
-	floss["greengraydk"] = "rgb(95, 102, 72)"
-	floss["greengraymd"] = "rgb(136, 146, 104)"
-	floss["greengray"] = "rgb(156, 164, 130)"
-	floss["desertsand"] = "rgb(196, 142, 112)"
-	floss["lemon"] = "rgb(253, 237, 84)"
-	floss["beavergrayvylt"] = "rgb(230, 232, 232)"
-	floss["goldenyellowvylt"] = "rgb(253, 249, 205)"
-	floss["rosedark"] = "rgb(86, 74, 74)"
-	floss["black"] = "rgb(0, 0, 0)"
-	floss["wedgewoodultvydk"] = "rgb(28, 80, 102)"
-	floss["babyblueverydark"] = "rgb(53, 102, 139)"
-	floss["antiquemauvemddk"] = "rgb(129, 73, 82)"
-	floss["antiquemauvemed"] = "rgb(183, 115, 127)"
-	floss["pewtergray"] = "rgb(108, 108, 108)"
-	floss["steelgraylt"] = "rgb(171, 171, 171)"
-	floss["pistachiogrnvydk"] = "rgb(32, 95, 46)"
-	floss["pistachiogreenmed"] = "rgb(105, 136, 90)"
-	floss["red"] = "rgb(199, 43, 59)"
-	floss["babybluedark"] = "rgb(90, 143, 184)"
-	floss["roseverydark"] = "rgb(179, 59, 75)"
-	floss["violetdark"] = "rgb(99, 54, 102)"
-	floss["babybluelight"] = "rgb(184, 210, 230)"
-	floss["roselight"] = "rgb(251, 173, 180)"
-	floss["salmondark"] = "rgb(227, 109, 109)"
-	floss["bluevioletverydark"] = "rgb(92, 84, 120)"
-	floss["babybluemedium"] = "rgb(115, 159, 193)"
-	floss["apricotmed"] = "rgb(255, 131, 111)"
-	floss["apricot"] = "rgb(252, 171, 152)"
-	floss["huntergreendk"] = "rgb(27, 89, 21)"
-	floss["huntergreen"] = "rgb(64, 106, 58)"
-	floss["yellowgreenmed"] = "rgb(113, 147, 92)"
-	floss["yellowgreenlt"] = "rgb(204, 217, 177)"
-	floss["rose"] = "rgb(238, 84, 110)"
-	floss["dustyroseultradark"] = "rgb(188, 67, 101)"
-	floss["dustyroselight"] = "rgb(228, 166, 172)"
-	floss["navyblue"] = "rgb(37, 59, 115)"
-	floss["pinegreendk"] = "rgb(94, 107, 71)"
-	floss["pinegreenmd"] = "rgb(114, 130, 86)"
-	floss["pinegreen"] = "rgb(131, 151, 95)"
-	floss["blackbrown"] = "rgb(30, 17, 8)"
-	floss["bluevioletmedium"] = "rgb(173, 167, 199)"
-	floss["bluevioletlight"] = "rgb(183, 191, 221)"
-	floss["salmonverydark"] = "rgb(191, 45, 45)"
-	floss["coraldark"] = "rgb(210, 16, 53)"
-	floss["coralmedium"] = "rgb(224, 72, 72)"
-	floss["coral"] = "rgb(233, 106, 103)"
-	floss["corallight"] = "rgb(253, 156, 151)"
-	floss["peach"] = "rgb(254, 215, 204)"
-	floss["terracottadark"] = "rgb(152, 68, 54)"
-	floss["terracottamed"] = "rgb(197, 106, 91)"
-	floss["plumlight"] = "rgb(197, 73, 137)"
-	floss["plumverylight"] = "rgb(234, 156, 196)"
-	floss["plumultralight"] = "rgb(244, 174, 213)"
-	floss["pistachiogreendk"] = "rgb(97, 122, 82)"
-	floss["pistachiogreenlt"] = "rgb(166, 194, 152)"
-	floss["mauveverydark"] = "rgb(136, 21, 49)"
-	floss["mauve"] = "rgb(201, 107, 112)"
-	floss["mauvemedium"] = "rgb(231, 169, 172)"
-	floss["mauvelight"] = "rgb(251, 191, 194)"
-	floss["pistachiogreenvylt"] = "rgb(215, 237, 204)"
-	floss["mustardmedium"] = "rgb(184, 157, 100)"
-	floss["melondark"] = "rgb(255, 121, 146)"
-	floss["melonmedium"] = "rgb(255, 173, 188)"
-	floss["melonlight"] = "rgb(255, 203, 213)"
-	floss["mustard"] = "rgb(191, 166, 113)"
-	floss["salmonmedium"] = "rgb(241, 135, 135)"
-	floss["salmonverylight"] = "rgb(255, 226, 226)"
-	floss["dustyrosemedvylt"] = "rgb(255, 189, 189)"
-	floss["mustardlt"] = "rgb(204, 183, 132)"
-	floss["shellpinkdark"] = "rgb(161, 75, 81)"
-	floss["shellpinkmed"] = "rgb(188, 108, 100)"
-	floss["antiquemauvedark"] = "rgb(155, 91, 102)"
-	floss["antiquemauvelight"] = "rgb(219, 169, 178)"
-	floss["dustyroseverydark"] = "rgb(218, 103, 131)"
-	floss["dustyrose"] = "rgb(232, 135, 155)"
-	floss["antiquevioletdark"] = "rgb(120, 87, 98)"
-	floss["antiquevioletvylt"] = "rgb(215, 203, 211)"
-	floss["bluevioletdark"] = "rgb(119, 107, 152)"
-	floss["bluevioletvylt"] = "rgb(211, 215, 237)"
-	floss["antiqueblueverydk"] = "rgb(56, 76, 94)"
-	floss["antiqueblueverylt"] = "rgb(199, 209, 219)"
-	floss["antiqueblueultvylt"] = "rgb(219, 226, 233)"
-	floss["babyblue"] = "rgb(147, 180, 206)"
-	floss["babyblueultvylt"] = "rgb(238, 252, 252)"
-	floss["wedgewoodmed"] = "rgb(62, 133, 162)"
-	floss["skybluelight"] = "rgb(172, 216, 226)"
-	floss["peacockbluevydk"] = "rgb(52, 127, 140)"
-	floss["peacockbluelight"] = "rgb(153, 207, 217)"
-	floss["graygreendark"] = "rgb(101, 127, 127)"
-	floss["tawnyvylight"] = "rgb(255, 238, 227)"
-	floss["terracottaultvylt"] = "rgb(244, 187, 169)"
-	floss["desertsandvydk"] = "rgb(160, 108, 80)"
-	floss["desertsanddark"] = "rgb(182, 117, 82)"
-	floss["desertsandvylt"] = "rgb(243, 225, 215)"
-	floss["mahoganylight"] = "rgb(207, 121, 57)"
-	floss["terracottavydk"] = "rgb(134, 48, 34)"
-	floss["terracottalight"] = "rgb(217, 137, 120)"
-	floss["rosewoodultvylt"] = "rgb(248, 202, 200)"
-	floss["mochabrowndk"] = "rgb(107, 87, 67)"
-	floss["mochabrownlt"] = "rgb(210, 188, 166)"
-	floss["browngraydark"] = "rgb(98, 93, 80)"
-	floss["beigegrayultdk"] = "rgb(127, 106, 85)"
-	floss["pewtergrayvydk"] = "rgb(66, 66, 66)"
-	floss["melonverydark"] = "rgb(231, 73, 103)"
-	floss["antiquemauvevydk"] = "rgb(113, 65, 73)"
-	floss["mauvedark"] = "rgb(171, 51, 87)"
-	floss["cyclamenpinkdark"] = "rgb(224, 40, 118)"
-	floss["cyclamenpink"] = "rgb(243, 71, 139)"
-	floss["cyclamenpinklight"] = "rgb(255, 140, 174)"
-	floss["cornflowerblue"] = "rgb(96, 103, 140)"
-	floss["turquoiseultvydk"] = "rgb(54, 105, 112)"
-	floss["turquoisevydark"] = "rgb(63, 124, 133)"
-	floss["turquoisedark"] = "rgb(72, 142, 154)"
-	floss["turquoiseverylight"] = "rgb(188, 227, 230)"
-	floss["seagreenvydk"] = "rgb(47, 140, 132)"
-	floss["bluegreenlt"] = "rgb(178, 212, 189)"
-	floss["aquamarine"] = "rgb(80, 139, 125)"
-	floss["celadongreendk"] = "rgb(71, 119, 89)"
-	floss["celadongreen"] = "rgb(101, 165, 125)"
-	floss["celadongreenlt"] = "rgb(153, 195, 170)"
-	floss["emeraldgrnultvdk"] = "rgb(17, 90, 59)"
-	floss["mossgreenlt"] = "rgb(224, 232, 104)"
-	floss["strawdark"] = "rgb(223, 182, 95)"
-	floss["straw"] = "rgb(243, 206, 117)"
-	floss["strawlight"] = "rgb(246, 220, 152)"
-	floss["yellowultrapale"] = "rgb(255, 253, 227)"
-	floss["apricotlight"] = "rgb(254, 205, 194)"
-	floss["pumpkinpale"] = "rgb(253, 189, 150)"
-	floss["goldenbrown"] = "rgb(173, 114, 57)"
-	floss["goldenbrownpale"] = "rgb(247, 187, 119)"
-	floss["hazelnutbrown"] = "rgb(183, 139, 97)"
-	floss["oldgoldvydark"] = "rgb(169, 130, 4)"
-	floss["terracotta"] = "rgb(185, 85, 68)"
-	floss["raspberrydark"] = "rgb(179, 47, 72)"
-	floss["raspberrymedium"] = "rgb(219, 85, 110)"
-	floss["raspberrylight"] = "rgb(234, 134, 153)"
-	floss["grapedark"] = "rgb(114, 55, 93)"
-	floss["grapemedium"] = "rgb(148, 96, 131)"
-	floss["grapelight"] = "rgb(186, 145, 170)"
-	floss["lavenderultradark"] = "rgb(108, 58, 110)"
-	floss["lavenderbluedark"] = "rgb(92, 114, 148)"
-	floss["lavenderbluemed"] = "rgb(123, 142, 171)"
-	floss["lavenderbluelight"] = "rgb(176, 192, 218)"
-	floss["babybluepale"] = "rgb(205, 223, 237)"
-	floss["wedgewoodvrydk"] = "rgb(50, 102, 124)"
-	floss["electricblue"] = "rgb(20, 170, 208)"
-	floss["turquoisebrightdark"] = "rgb(18, 174, 186)"
-	floss["turquoisebrightmed"] = "rgb(4, 196, 202)"
-	floss["turquoisebrightlight"] = "rgb(6, 227, 230)"
-	floss["tealgreendark"] = "rgb(52, 125, 117)"
-	floss["tealgreenmed"] = "rgb(85, 147, 146)"
-	floss["tealgreenlight"] = "rgb(82, 179, 164)"
-	floss["greenbrightdk"] = "rgb(55, 132, 119)"
-	floss["greenbrightlt"] = "rgb(73, 179, 161)"
-	floss["strawverydark"] = "rgb(205, 157, 55)"
-	floss["autumngolddk"] = "rgb(242, 151, 70)"
-	floss["autumngoldmed"] = "rgb(242, 175, 104)"
-	floss["autumngoldlt"] = "rgb(250, 211, 150)"
-	floss["mahoganyultvylt"] = "rgb(255, 211, 181)"
-	floss["rosewooddark"] = "rgb(104, 37, 26)"
-	floss["rosewoodmed"] = "rgb(150, 74, 63)"
-	floss["rosewoodlight"] = "rgb(186, 139, 124)"
-	floss["cocoa"] = "rgb(125, 93, 87)"
-	floss["cocoalight"] = "rgb(166, 136, 129)"
-	floss["mochabeigedark"] = "rgb(138, 110, 78)"
-	floss["mochabeigemed"] = "rgb(164, 131, 92)"
-	floss["mochabeigelight"] = "rgb(203, 182, 156)"
-	floss["winterwhite"] = "rgb(249, 247, 241)"
-	floss["mochabrnultvylt"] = "rgb(250, 246, 240)"
-	floss["mahoganydark"] = "rgb(143, 67, 15)"
-	floss["mahoganyvylt"] = "rgb(247, 167, 119)"
-	floss["desertsandmed"] = "rgb(187, 129, 97)"
-	floss["pewtergraydark"] = "rgb(86, 86, 86)"
-	floss["steelgraydk"] = "rgb(140, 140, 140)"
-	floss["pearlgray"] = "rgb(211, 211, 214)"
-	floss["hazelnutbrowndk"] = "rgb(160, 112, 66)"
-	floss["hazelnutbrownlt"] = "rgb(198, 159, 123)"
-	floss["brownmed"] = "rgb(122, 69, 31)"
-	floss["brownlight"] = "rgb(152, 94, 51)"
-	floss["brownverylight"] = "rgb(184, 119, 72)"
-	floss["tan"] = "rgb(203, 144, 81)"
-	floss["tanlight"] = "rgb(228, 187, 142)"
-	floss["lemondark"] = "rgb(255, 214, 0)"
-	floss["lemonlight"] = "rgb(255, 251, 139)"
-	floss["shellgraydark"] = "rgb(145, 123, 115)"
-	floss["shellgraymed"] = "rgb(192, 179, 174)"
-	floss["shellgraylight"] = "rgb(215, 206, 203)"
-	floss["avocadogreen"] = "rgb(114, 132, 60)"
-	floss["avocadogrnlt"] = "rgb(148, 171, 79)"
-	floss["avocadogrnvlt"] = "rgb(174, 191, 121)"
-	floss["avocadogrnult"] = "rgb(216, 228, 152)"
-	floss["reddark"] = "rgb(167, 19, 43)"
-	floss["bluegreenvydk"] = "rgb(4, 77, 51)"
-	floss["bluegreendark"] = "rgb(57, 111, 82)"
-	floss["bluegreen"] = "rgb(91, 144, 113)"
-	floss["bluegreenmed"] = "rgb(123, 172, 148)"
-	floss["bluegreenvylt"] = "rgb(196, 222, 204)"
-	floss["jadegreen"] = "rgb(51, 131, 98)"
-	floss["wedgewooddark"] = "rgb(59, 118, 143)"
-	floss["wedgewoodlight"] = "rgb(79, 147, 167)"
-	floss["skyblue"] = "rgb(126, 177, 200)"
-	floss["ferngreendark"] = "rgb(102, 109, 79)"
-	floss["ferngreen"] = "rgb(150, 158, 126)"
-	floss["ferngreenlt"] = "rgb(171, 177, 151)"
-	floss["ferngreenvylt"] = "rgb(196, 205, 172)"
-	floss["ashgrayvylt"] = "rgb(99, 100, 88)"
-	floss["beigebrownultvylt"] = "rgb(242, 227, 206)"
-	floss["violetverydark"] = "rgb(92, 24, 78)"
-	floss["violetmedium"] = "rgb(128, 58, 107)"
-	floss["violet"] = "rgb(163, 99, 139)"
-	floss["violetlight"] = "rgb(219, 179, 203)"
-	floss["celadongreenvd"] = "rgb(44, 106, 69)"
-	floss["jademedium"] = "rgb(83, 151, 106)"
-	floss["jadelight"] = "rgb(143, 192, 152)"
-	floss["jadeverylight"] = "rgb(167, 205, 175)"
-	floss["mossgreendk"] = "rgb(136, 141, 51)"
-	floss["mossgreen"] = "rgb(167, 174, 56)"
-	floss["turquoise"] = "rgb(91, 163, 179)"
-	floss["turquoiselight"] = "rgb(144, 195, 204)"
-	floss["cranberryverydark"] = "rgb(205, 47, 99)"
-	floss["cranberrydark"] = "rgb(209, 40, 106)"
-	floss["cranberrymedium"] = "rgb(226, 72, 116)"
-	floss["cranberry"] = "rgb(255, 164, 190)"
-	floss["cranberrylight"] = "rgb(255, 176, 190)"
-	floss["cranberryverylight"] = "rgb(255, 192, 205)"
-	floss["orangeredbright"] = "rgb(250, 50, 3)"
-	floss["burntorangebright"] = "rgb(253, 93, 53)"
-	floss["drabbrowndk"] = "rgb(121, 96, 71)"
-	floss["drabbrown"] = "rgb(150, 118, 86)"
-	floss["drabbrownlt"] = "rgb(188, 154, 120)"
-	floss["drabbrownvlt"] = "rgb(220, 196, 170)"
-	floss["desertsandultvydk"] = "rgb(135, 85, 57)"
-	floss["beigegrayvydk"] = "rgb(133, 123, 97)"
-	floss["beigegraydark"] = "rgb(164, 152, 120)"
-	floss["beigegraymed"] = "rgb(221, 216, 203)"
-	floss["beavergrayvydk"] = "rgb(110, 101, 92)"
-	floss["beavergraydk"] = "rgb(135, 125, 115)"
-	floss["beavergraymed"] = "rgb(176, 166, 156)"
-	floss["beavergraylt"] = "rgb(188, 180, 172)"
-	floss["brightred"] = "rgb(227, 29, 66)"
-	floss["oldgoldlt"] = "rgb(229, 206, 151)"
-	floss["oldgoldvylt"] = "rgb(245, 236, 203)"
-	floss["oldgolddark"] = "rgb(188, 141, 14)"
-	floss["green"] = "rgb(5, 101, 23)"
-	floss["greenbright"] = "rgb(7, 115, 27)"
-	floss["greenlight"] = "rgb(63, 143, 41)"
-	floss["kellygreen"] = "rgb(71, 167, 47)"
-	floss["chartreuse"] = "rgb(123, 181, 71)"
-	floss["chartreusebright"] = "rgb(158, 207, 52)"
-	floss["cream"] = "rgb(255, 251, 239)"
-	floss["plum"] = "rgb(156, 36, 98)"
-	floss["orangespicedark"] = "rgb(229, 92, 31)"
-	floss["orangespicemed"] = "rgb(242, 120, 66)"
-	floss["orangespicelight"] = "rgb(247, 151, 111)"
-	floss["topazmedlt"] = "rgb(255, 200, 64)"
-	floss["topazlight"] = "rgb(253, 215, 85)"
-	floss["topazvylt"] = "rgb(255, 241, 175)"
-	floss["topaz"] = "rgb(228, 180, 104)"
-	floss["oldgoldmedium"] = "rgb(208, 165, 62)"
-	floss["olivegreenvdk"] = "rgb(130, 123, 48)"
-	floss["olivegreendk"] = "rgb(147, 139, 55)"
-	floss["olivegreen"] = "rgb(148, 140, 54)"
-	floss["olivegreenmd"] = "rgb(188, 179, 76)"
-	floss["olivegreenlt"] = "rgb(199, 192, 119)"
-	floss["tanverylight"] = "rgb(236, 204, 158)"
-	floss["tanultvylt"] = "rgb(248, 228, 200)"
-	floss["tangerine"] = "rgb(255, 139, 0)"
-	floss["tangerinemed"] = "rgb(255, 163, 43)"
-	floss["tangerinelight"] = "rgb(255, 191, 87)"
-	floss["yellowmed"] = "rgb(254, 211, 118)"
-	floss["yellowpale"] = "rgb(255, 231, 147)"
-	floss["yellowpalelight"] = "rgb(255, 233, 173)"
-	floss["offwhite"] = "rgb(252, 252, 238)"
-	floss["peacockbluevylt"] = "rgb(229, 252, 253)"
-	floss["peachlight"] = "rgb(247, 203, 191)"
-	floss["terracottavylt"] = "rgb(238, 170, 155)"
-	floss["salmon"] = "rgb(245, 173, 173)"
-	floss["salmonlight"] = "rgb(255, 201, 201)"
-	floss["pearlgrayvylt"] = "rgb(236, 236, 236)"
-	floss["yellowgreenvylt"] = "rgb(228, 236, 212)"
-	floss["babyblueverylight"] = "rgb(217, 235, 241)"
-	floss["pinkmedium"] = "rgb(252, 176, 185)"
-	floss["raspberryverydark"] = "rgb(145, 53, 70)"
-	floss["antiquemauvevylt"] = "rgb(223, 179, 187)"
-	floss["cocoadark"] = "rgb(98, 75, 69)"
-	floss["topazultravydk"] = "rgb(148, 99, 26)"
-	floss["topazverydark"] = "rgb(162, 109, 32)"
-	floss["topazdark"] = "rgb(174, 119, 32)"
-	floss["topazmedium"] = "rgb(206, 145, 36)"
-	floss["cornflowerbluevd"] = "rgb(70, 69, 99)"
-	floss["cornflowerbluedark"] = "rgb(85, 91, 123)"
-	floss["cornflowerbluemed"] = "rgb(112, 125, 162)"
-	floss["cornflowerbluelight"] = "rgb(143, 156, 193)"
-	floss["royalbluedark"] = "rgb(17, 65, 109)"
-	floss["royalblue"] = "rgb(19, 71, 125)"
-	floss["delftbluedark"] = "rgb(70, 106, 142)"
-	floss["delftbluemedium"] = "rgb(116, 142, 182)"
-	floss["delftbluepale"] = "rgb(192, 204, 222)"
-	floss["coffeebrowndk"] = "rgb(101, 57, 25)"
-	floss["babyblueultvydk"] = "rgb(44, 89, 124)"
-	floss["peacockbluedark"] = "rgb(61, 149, 165)"
-	floss["peacockblue"] = "rgb(100, 171, 186)"
-	floss["delftblue"] = "rgb(148, 168, 198)"
-	floss["bluelight"] = "rgb(161, 194, 215)"
-	floss["garnetdark"] = "rgb(123, 0, 27)"
-	floss["garnetmedium"] = "rgb(135, 7, 31)"
-	floss["garnet"] = "rgb(151, 11, 35)"
-	floss["coralredverydark"] = "rgb(187, 5, 31)"
-	floss["babypink"] = "rgb(255, 223, 217)"
-	floss["babypinklight"] = "rgb(255, 238, 235)"
-	floss["royalblueverydark"] = "rgb(14, 54, 92)"
-	floss["beigegraylight"] = "rgb(231, 226, 211)"
-	floss["navybluedark"] = "rgb(33, 48, 99)"
-	floss["blueverydark"] = "rgb(57, 105, 135)"
-	floss["bluedark"] = "rgb(71, 129, 165)"
-	floss["bluemedium"] = "rgb(107, 158, 191)"
-	floss["blueverylight"] = "rgb(189, 221, 237)"
-	floss["skybluevylt"] = "rgb(197, 232, 237)"
-	floss["goldenolivevydk"] = "rgb(126, 107, 66)"
-	floss["goldenolivedk"] = "rgb(141, 120, 75)"
-	floss["goldenolivemd"] = "rgb(170, 143, 86)"
-	floss["goldenolive"] = "rgb(189, 155, 81)"
-	floss["goldenolivelt"] = "rgb(200, 171, 108)"
-	floss["goldenolivevylt"] = "rgb(219, 190, 127)"
-	floss["beigebrownvydk"] = "rgb(89, 73, 55)"
-	floss["beigebrowndk"] = "rgb(103, 85, 65)"
-	floss["beigebrownmed"] = "rgb(154, 124, 92)"
-	floss["beigebrownlt"] = "rgb(182, 155, 126)"
-	floss["beigebrownvylt"] = "rgb(209, 186, 161)"
-	floss["beavergrayultdk"] = "rgb(72, 72, 72)"
-	floss["hazelnutbrownvdk"] = "rgb(131, 94, 57)"
-	floss["pistachiogrnultvd"] = "rgb(23, 73, 35)"
-	floss["carnationdark"] = "rgb(255, 87, 115)"
-	floss["carnationmedium"] = "rgb(255, 121, 140)"
-	floss["carnationlight"] = "rgb(252, 144, 162)"
-	floss["carnationverylight"] = "rgb(255, 178, 187)"
-	floss["huntergreenvydk"] = "rgb(27, 83, 0)"
-	floss["coffeebrownvydk"] = "rgb(73, 42, 19)"
-	floss["rosemedium"] = "rgb(242, 118, 136)"
-	floss["burntorangedark"] = "rgb(209, 88, 7)"
-	floss["garnetverydark"] = "rgb(130, 38, 55)"
-	floss["parrotgreenvdk"] = "rgb(85, 120, 34)"
-	floss["parrotgreendk"] = "rgb(98, 138, 40)"
-	floss["parrotgreenmd"] = "rgb(127, 179, 53)"
-	floss["parrotgreenlt"] = "rgb(199, 230, 102)"
-	floss["emeraldgreenvydk"] = "rgb(21, 111, 73)"
-	floss["emeraldgreendark"] = "rgb(24, 126, 86)"
-	floss["emeraldgreenmed"] = "rgb(24, 144, 101)"
-	floss["emeraldgreenlt"] = "rgb(27, 157, 107)"
-	floss["nilegreenmed"] = "rgb(109, 171, 119)"
-	floss["plumdark"] = "rgb(130, 0, 67)"
-	floss["plummedium"] = "rgb(155, 19, 89)"
-	floss["redcopperdark"] = "rgb(130, 52, 10)"
-	floss["redcopper"] = "rgb(166, 69, 16)"
-	floss["coppermed"] = "rgb(172, 84, 20)"
-	floss["copper"] = "rgb(198, 98, 24)"
-	floss["copperlight"] = "rgb(226, 115, 35)"
-	floss["graygreenvydark"] = "rgb(86, 106, 106)"
-	floss["graygreenmed"] = "rgb(152, 174, 174)"
-	floss["graygreenlight"] = "rgb(189, 203, 203)"
-	floss["graygreenvylt"] = "rgb(221, 227, 227)"
-	floss["antiquebluedark"] = "rgb(69, 92, 113)"
-	floss["antiquebluemedium"] = "rgb(106, 133, 158)"
-	floss["antiquebluelight"] = "rgb(162, 181, 198)"
-	floss["avocadogrnblack"] = "rgb(49, 57, 25)"
-	floss["avocadogreendk"] = "rgb(66, 77, 33)"
-	floss["avocadogrnvdk"] = "rgb(76, 88, 38)"
-	floss["avocadogreenmd"] = "rgb(98, 113, 51)"
-	floss["coffeebrownultdk"] = "rgb(54, 31, 14)"
-	floss["navyblueverydark"] = "rgb(27, 40, 83)"
-	floss["greenbrightmd"] = "rgb(61, 147, 132)"
-	floss["tawny"] = "rgb(251, 213, 187)"
-	floss["burntorangemed"] = "rgb(235, 99, 7)"
-	floss["burntorange"] = "rgb(255, 123, 77)"
-	floss["peachverylight"] = "rgb(254, 231, 218)"
-	floss["desertsandlight"] = "rgb(238, 211, 196)"
-	floss["tawnylight"] = "rgb(255, 226, 207)"
-	floss["nilegreen"] = "rgb(136, 186, 145)"
-	floss["nilegreenlight"] = "rgb(162, 214, 173)"
-	floss["geranium"] = "rgb(255, 145, 145)"
-	floss["geraniumpale"] = "rgb(253, 181, 181)"
-	floss["seagreendark"] = "rgb(62, 182, 161)"
-	floss["seagreenmed"] = "rgb(89, 199, 180)"
-	floss["dustyrosedark"] = "rgb(207, 115, 115)"
-	floss["dustyrosemedium"] = "rgb(230, 138, 138)"
-	floss["dustyroseultvylt"] = "rgb(255, 215, 215)"
-	floss["seagreenlight"] = "rgb(169, 226, 216)"
-	floss["jadeultravylt"] = "rgb(185, 215, 192)"
-	floss["apricotverylight"] = "rgb(255, 222, 213)"
-	floss["pumpkinlight"] = "rgb(247, 139, 19)"
-	floss["pumpkin"] = "rgb(246, 127, 0)"
-	floss["canarydeep"] = "rgb(255, 181, 21)"
-	floss["canarybright"] = "rgb(255, 227, 0)"
-	floss["goldenbrowndk"] = "rgb(145, 79, 18)"
-	floss["goldenbrownmed"] = "rgb(194, 129, 66)"
-	floss["goldenbrownlight"] = "rgb(220, 156, 86)"
-	floss["forestgreenvydk"] = "rgb(64, 82, 48)"
-	floss["forestgreendk"] = "rgb(88, 113, 65)"
-	floss["forestgreenmed"] = "rgb(115, 139, 91)"
-	floss["forestgreen"] = "rgb(141, 166, 117)"
-	floss["aquamarinedk"] = "rgb(71, 123, 110)"
-	floss["aquamarinelt"] = "rgb(111, 174, 159)"
-	floss["aquamarinevylt"] = "rgb(144, 192, 180)"
-	floss["electricbluedark"] = "rgb(38, 150, 182)"
-	floss["electricbluemedium"] = "rgb(48, 194, 236)"
-	floss["snowwhite"] = "rgb(255, 255, 255)"
-	floss["ecru"] = "rgb(240, 234, 218)"
-	floss["white"] = "rgb(252, 251, 248)"
-	return floss
+type (
+	flossThread struct {
+		brand string
+		code  string
+		name  string
+		rgb   string
+	}
+)
+
+func dmcThreads() []flossThread {
+	return []flossThread{
+		{code: "150", name: "dustyroseultvydk", rgb: "rgb(171, 2, 73)"},
+		{code: "151", name: "dustyrosevrylt", rgb: "rgb(240, 206, 212)"},
+		{code: "152", name: "shellpinkmedlight", rgb: "rgb(226, 160, 153)"},
+		{code: "153", name: "violetverylight", rgb: "rgb(230, 204, 217)"},
+		{code: "154", name: "grapeverydark", rgb: "rgb(87, 36, 51)"},
+		{code: "155", name: "bluevioletmeddark", rgb: "rgb(152, 145, 182)"},
+		{code: "156", name: "bluevioletmedlt", rgb: "rgb(163, 174, 209)"},
+		{code: "157", name: "cornflowerbluevylt", rgb: "rgb(187, 195, 217)"},
+		{code: "158", name: "cornflowerblumvd", rgb: "rgb(76, 82, 110)"},
+		{code: "159", name: "bluegraylight", rgb: "rgb(199, 202, 215)"},
+		{code: "160", name: "bluegraymedium", rgb: "rgb(153, 159, 183)"},
+		{code: "161", name: "bluegray", rgb: "rgb(120, 128, 164)"},
+		{code: "162", name: "blueultraverylight", rgb: "rgb(219, 236, 245)"},
+		{code: "163", name: "celadongreenmd", rgb: "rgb(77, 131, 97)"},
+		{code: "164", name: "forestgreenlt", rgb: "rgb(200, 216, 184)"},
+		{code: "165", name: "mossgreenvylt", rgb: "rgb(239, 244, 164)"},
+		{code: "166", name: "mossgreenmdlt", rgb: "rgb(192, 200, 64)"},
+		{code: "167", name: "yellowbeigevdk", rgb: "rgb(167, 124, 73)"},
+		{code: "168", name: "pewterverylight", rgb: "rgb(209, 209, 209)"},
+		{code: "169", name: "pewterlight", rgb: "rgb(132, 132, 132)"},
+		{code: "208", name: "lavenderverydark", rgb: "rgb(131, 91, 139)"},
+		{code: "209", name: "lavenderdark", rgb: "rgb(163, 123, 167)"},
+		{code: "210", name: "lavendermedium", rgb: "rgb(195, 159, 195)"},
+		{code: "211", name: "lavenderlight", rgb: "rgb(227, 203, 227)"},
+		{code: "221", name: "shellpinkvydk", rgb: "rgb(136, 62, 67)"},
+		{code: "223", name: "shellpinklight", rgb: "rgb(204, 132, 124)"},
+		{code: "224", name: "shellpinkverylight", rgb: "rgb(235, 183, 175)"},
+		{code: "225", name: "shellpinkultvylt", rgb: "rgb(255, 223, 213)"},
+		{code: "300", name: "mahoganyvydk", rgb: "rgb(111, 47, 0)"},
+		{code: "301", name: "mahoganymed", rgb: "rgb(179, 95, 43)"},
+		{code: "3011", name: "khakigreendk", rgb: "rgb(137, 138, 88)"},
+		{code: "3012", name: "khakigreenmd", rgb: "rgb(166, 167, 93)"},
+		{code: "3013", name: "khakigreenlt", rgb: "rgb(185, 185, 130)"},
+		{code: "3021", name: "browngrayvydk", rgb: "rgb(79, 75, 65)"},
+		{code: "3022", name: "browngraymed", rgb: "rgb(142, 144, 120)"},
+		{code: "3023", name: "browngraylight", rgb: "rgb(177, 170, 151)"},
+		{code: "3024", name: "browngrayvylt", rgb: "rgb(235, 234, 231)"},
+		{code: "3031", name: "mochabrownvydk", rgb: "rgb(75, 60, 42)"},
+		{code: "3032", name: "mochabrownmed", rgb: "rgb(179, 159, 139)"},
+		{code: "3033", name: "mochabrownvylt", rgb: "rgb(227, 216, 204)"},
+		{code: "304", name: "redmedium", rgb: "rgb(183, 31, 51)"},
+		{code: "3041", name: "antiquevioletmedium", rgb: "rgb(149, 111, 124)"},
+		{code: "3042", name: "antiquevioletlight", rgb: "rgb(183, 157, 167)"},
+		{code: "3045", name: "yellowbeigedk", rgb: "rgb(188, 150, 106)"},
+		{code: "3046", name: "yellowbeigemd", rgb: "rgb(216, 188, 154)"},
+		{code: "3047", name: "yellowbeigelt", rgb: "rgb(231, 214, 193)"},
+		{code: "3051", name: "greengraydk", rgb: "rgb(95, 102, 72)"},
+		{code: "3052", name: "greengraymd", rgb: "rgb(136, 146, 104)"},
+		{code: "3053", name: "greengray", rgb: "rgb(156, 164, 130)"},
+		{code: "3064", name: "desertsand", rgb: "rgb(196, 142, 112)"},
+		{code: "307", name: "lemon", rgb: "rgb(253, 237, 84)"},
+		{code: "3072", name: "beavergrayvylt", rgb: "rgb(230, 232, 232)"},
+		{code: "3078", name: "goldenyellowvylt", rgb: "rgb(253, 249, 205)"},
+		{code: "309", name: "rosedark", rgb: "rgb(86, 74, 74)"},
+		{code: "310", name: "black", rgb: "rgb(0, 0, 0)"},
+		{code: "311", name: "wedgewoodultvydk", rgb: "rgb(28, 80, 102)"},
+		{code: "312", name: "babyblueverydark", rgb: "rgb(53, 102, 139)"},
+		{code: "315", name: "antiquemauvemddk", rgb: "rgb(129, 73, 82)"},
+		{code: "316", name: "antiquemauvemed", rgb: "rgb(183, 115, 127)"},
+		{code: "317", name: "pewtergray", rgb: "rgb(108, 108, 108)"},
+		{code: "318", name: "steelgraylt", rgb: "rgb(171, 171, 171)"},
+		{code: "319", name: "pistachiogrnvydk", rgb: "rgb(32, 95, 46)"},
+		{code: "320", name: "pistachiogreenmed", rgb: "rgb(105, 136, 90)"},
+		{code: "321", name: "red", rgb: "rgb(199, 43, 59)"},
+		{code: "322", name: "babybluedark", rgb: "rgb(90, 143, 184)"},
+		{code: "326", name: "roseverydark", rgb: "rgb(179, 59, 75)"},
+		{code: "327", name: "violetdark", rgb: "rgb(99, 54, 102)"},
+		{code: "3325", name: "babybluelight", rgb: "rgb(184, 210, 230)"},
+		{code: "3326", name: "roselight", rgb: "rgb(251, 173, 180)"},
+		{code: "3328", name: "salmondark", rgb: "rgb(227, 109, 109)"},
+		{code: "333", name: "bluevioletverydark", rgb: "rgb(92, 84, 120)"},
+		{code: "334", name: "babybluemedium", rgb: "rgb(115, 159, 193)"},
+		{code: "3340", name: "apricotmed", rgb: "rgb(255, 131, 111)"},
+		{code: "3341", name: "apricot", rgb: "rgb(252, 171, 152)"},
+		{code: "3345", name: "huntergreendk", rgb: "rgb(27, 89, 21)"},
+		{code: "3346", name: "huntergreen", rgb: "rgb(64, 106, 58)"},
+		{code: "3347", name: "yellowgreenmed", rgb: "rgb(113, 147, 92)"},
+		{code: "3348", name: "yellowgreenlt", rgb: "rgb(204, 217, 177)"},
+		{code: "335", name: "rose", rgb: "rgb(238, 84, 110)"},
+		{code: "3350", name: "dustyroseultradark", rgb: "rgb(188, 67, 101)"},
+		{code: "3354", name: "dustyroselight", rgb: "rgb(228, 166, 172)"},
+		{code: "336", name: "navyblue", rgb: "rgb(37, 59, 115)"},
+		{code: "3362", name: "pinegreendk", rgb: "rgb(94, 107, 71)"},
+		{code: "3363", name: "pinegreenmd", rgb: "rgb(114, 130, 86)"},
+		{code: "3364", name: "pinegreen", rgb: "rgb(131, 151, 95)"},
+		{code: "3371", name: "blackbrown", rgb: "rgb(30, 17, 8)"},
+		{code: "340", name: "bluevioletmedium", rgb: "rgb(173, 167, 199)"},
+		{code: "341", name: "bluevioletlight", rgb: "rgb(183, 191, 221)"},
+		{code: "347", name: "salmonverydark", rgb: "rgb(191, 45, 45)"},
+		{code: "349", name: "coraldark", rgb: "rgb(210, 16, 53)"},
+		{code: "350", name: "coralmedium", rgb: "rgb(224, 72, 72)"},
+		{code: "351", name: "coral", rgb: "rgb(233, 106, 103)"},
+		{code: "352", name: "corallight", rgb: "rgb(253, 156, 151)"},
+		{code: "353", name: "peach", rgb: "rgb(254, 215, 204)"},
+		{code: "355", name: "terracottadark", rgb: "rgb(152, 68, 54)"},
+		{code: "356", name: "terracottamed", rgb: "rgb(197, 106, 91)"},
+		{code: "3607", name: "plumlight", rgb: "rgb(197, 73, 137)"},
+		{code: "3608", name: "plumverylight", rgb: "rgb(234, 156, 196)"},
+		{code: "3609", name: "plumultralight", rgb: "rgb(244, 174, 213)"},
+		{code: "367", name: "pistachiogreendk", rgb: "rgb(97, 122, 82)"},
+		{code: "368", name: "pistachiogreenlt", rgb: "rgb(166, 194, 152)"},
+		{code: "3685", name: "mauveverydark", rgb: "rgb(136, 21, 49)"},
+		{code: "3687", name: "mauve", rgb: "rgb(201, 107, 112)"},
+		{code: "3688", name: "mauvemedium", rgb: "rgb(231, 169, 172)"},
+		{code: "3689", name: "mauvelight", rgb: "rgb(251, 191, 194)"},
+		{code: "369", name: "pistachiogreenvylt", rgb: "rgb(215, 237, 204)"},
+		{code: "370", name: "mustardmedium", rgb: "rgb(184, 157, 100)"},
+		{code: "3705", name: "melondark", rgb: "rgb(255, 121, 146)"},
+		{code: "3706", name: "melonmedium", rgb: "rgb(255, 173, 188)"},
+		{code: "3708", name: "melonlight", rgb: "rgb(255, 203, 213)"},
+		{code: "371", name: "mustard", rgb: "rgb(191, 166, 113)"},
+		{code: "3712", name: "salmonmedium", rgb: "rgb(241, 135, 135)"},
+		{code: "3713", name: "salmonverylight", rgb: "rgb(255, 226, 226)"},
+		{code: "3716", name: "dustyrosemedvylt", rgb: "rgb(255, 189, 189)"},
+		{code: "372", name: "mustardlt", rgb: "rgb(204, 183, 132)"},
+		{code: "3721", name: "shellpinkdark", rgb: "rgb(161, 75, 81)"},
+		{code: "3722", name: "shellpinkmed", rgb: "rgb(188, 108, 100)"},
+		{code: "3726", name: "antiquemauvedark", rgb: "rgb(155, 91, 102)"},
+		{code: "3727", name: "antiquemauvelight", rgb: "rgb(219, 169, 178)"},
+		{code: "3731", name: "dustyroseverydark", rgb: "rgb(218, 103, 131)"},
+		{code: "3733", name: "dustyrose", rgb: "rgb(232, 135, 155)"},
+		{code: "3740", name: "antiquevioletdark", rgb: "rgb(120, 87, 98)"},
+		{code: "3743", name: "antiquevioletvylt", rgb: "rgb(215, 203, 211)"},
+		{code: "3746", name: "bluevioletdark", rgb: "rgb(119, 107, 152)"},
+		{code: "3747", name: "bluevioletvylt", rgb: "rgb(211, 215, 237)"},
+		{code: "3750", name: "antiqueblueverydk", rgb: "rgb(56, 76, 94)"},
+		{code: "3752", name: "antiqueblueverylt", rgb: "rgb(199, 209, 219)"},
+		{code: "3753", name: "antiqueblueultvylt", rgb: "rgb(219, 226, 233)"},
+		{code: "3755", name: "babyblue", rgb: "rgb(147, 180, 206)"},
+		{code: "3756", name: "babyblueultvylt", rgb: "rgb(238, 252, 252)"},
+		{code: "3760", name: "wedgewoodmed", rgb: "rgb(62, 133, 162)"},
+		{code: "3761", name: "skybluelight", rgb: "rgb(172, 216, 226)"},
+		{code: "3765", name: "peacockbluevydk", rgb: "rgb(52, 127, 140)"},
+		{code: "3766", name: "peacockbluelight", rgb: "rgb(153, 207, 217)"},
+		{code: "3768", name: "graygreendark", rgb: "rgb(101, 127, 127)"},
+		{code: "3770", name: "tawnyvylight", rgb: "rgb(255, 238, 227)"},
+		{code: "3771", name: "terracottaultvylt", rgb: "rgb(244, 187, 169)"},
+		{code: "3772", name: "desertsandvydk", rgb: "rgb(160, 108, 80)"},
+		{code: "3773", name: "desertsanddark", rgb: "rgb(182, 117, 82)"},
+		{code: "3774", name: "desertsandvylt", rgb: "rgb(243, 225, 215)"},
+		{code: "3776", name: "mahoganylight", rgb: "rgb(207, 121, 57)"},
+		{code: "3777", name: "terracottavydk", rgb: "rgb(134, 48, 34)"},
+		{code: "3778", name: "terracottalight", rgb: "rgb(217, 137, 120)"},
+		{code: "3779", name: "rosewoodultvylt", rgb: "rgb(248, 202, 200)"},
+		{code: "3781", name: "mochabrowndk", rgb: "rgb(107, 87, 67)"},
+		{code: "3782", name: "mochabrownlt", rgb: "rgb(210, 188, 166)"},
+		{code: "3787", name: "browngraydark", rgb: "rgb(98, 93, 80)"},
+		{code: "3790", name: "beigegrayultdk", rgb: "rgb(127, 106, 85)"},
+		{code: "3799", name: "pewtergrayvydk", rgb: "rgb(66, 66, 66)"},
+		{code: "3801", name: "melonverydark", rgb: "rgb(231, 73, 103)"},
+		{code: "3802", name: "antiquemauvevydk", rgb: "rgb(113, 65, 73)"},
+		{code: "3803", name: "mauvedark", rgb: "rgb(171, 51, 87)"},
+		{code: "3804", name: "cyclamenpinkdark", rgb: "rgb(224, 40, 118)"},
+		{code: "3805", name: "cyclamenpink", rgb: "rgb(243, 71, 139)"},
+		{code: "3806", name: "cyclamenpinklight", rgb: "rgb(255, 140, 174)"},
+		{code: "3807", name: "cornflowerblue", rgb: "rgb(96, 103, 140)"},
+		{code: "3808", name: "turquoiseultvydk", rgb: "rgb(54, 105, 112)"},
+		{code: "3809", name: "turquoisevydark", rgb: "rgb(63, 124, 133)"},
+		{code: "3810", name: "turquoisedark", rgb: "rgb(72, 142, 154)"},
+		{code: "3811", name: "turquoiseverylight", rgb: "rgb(188, 227, 230)"},
+		{code: "3812", name: "seagreenvydk", rgb: "rgb(47, 140, 132)"},
+		{code: "3813", name: "bluegreenlt", rgb: "rgb(178, 212, 189)"},
+		{code: "3814", name: "aquamarine", rgb: "rgb(80, 139, 125)"},
+		{code: "3815", name: "celadongreendk", rgb: "rgb(71, 119, 89)"},
+		{code: "3816", name: "celadongreen", rgb: "rgb(101, 165, 125)"},
+		{code: "3817", name: "celadongreenlt", rgb: "rgb(153, 195, 170)"},
+		{code: "3818", name: "emeraldgrnultvdk", rgb: "rgb(17, 90, 59)"},
+		{code: "3819", name: "mossgreenlt", rgb: "rgb(224, 232, 104)"},
+		{code: "3820", name: "strawdark", rgb: "rgb(223, 182, 95)"},
+		{code: "3821", name: "straw", rgb: "rgb(243, 206, 117)"},
+		{code: "3822", name: "strawlight", rgb: "rgb(246, 220, 152)"},
+		{code: "3823", name: "yellowultrapale", rgb: "rgb(255, 253, 227)"},
+		{code: "3824", name: "apricotlight", rgb: "rgb(254, 205, 194)"},
+		{code: "3825", name: "pumpkinpale", rgb: "rgb(253, 189, 150)"},
+		{code: "3826", name: "goldenbrown", rgb: "rgb(173, 114, 57)"},
+		{code: "3827", name: "goldenbrownpale", rgb: "rgb(247, 187, 119)"},
+		{code: "3828", name: "hazelnutbrown", rgb: "rgb(183, 139, 97)"},
+		{code: "3829", name: "oldgoldvydark", rgb: "rgb(169, 130, 4)"},
+		{code: "3830", name: "terracotta", rgb: "rgb(185, 85, 68)"},
+		{code: "3831", name: "raspberrydark", rgb: "rgb(179, 47, 72)"},
+		{code: "3832", name: "raspberrymedium", rgb: "rgb(219, 85, 110)"},
+		{code: "3833", name: "raspberrylight", rgb: "rgb(234, 134, 153)"},
+		{code: "3834", name: "grapedark", rgb: "rgb(114, 55, 93)"},
+		{code: "3835", name: "grapemedium", rgb: "rgb(148, 96, 131)"},
+		{code: "3836", name: "grapelight", rgb: "rgb(186, 145, 170)"},
+		{code: "3837", name: "lavenderultradark", rgb: "rgb(108, 58, 110)"},
+		{code: "3838", name: "lavenderbluedark", rgb: "rgb(92, 114, 148)"},
+		{code: "3839", name: "lavenderbluemed", rgb: "rgb(123, 142, 171)"},
+		{code: "3840", name: "lavenderbluelight", rgb: "rgb(176, 192, 218)"},
+		{code: "3841", name: "babybluepale", rgb: "rgb(205, 223, 237)"},
+		{code: "3842", name: "wedgewoodvrydk", rgb: "rgb(50, 102, 124)"},
+		{code: "3843", name: "electricblue", rgb: "rgb(20, 170, 208)"},
+		{code: "3844", name: "turquoisebrightdark", rgb: "rgb(18, 174, 186)"},
+		{code: "3845", name: "turquoisebrightmed", rgb: "rgb(4, 196, 202)"},
+		{code: "3846", name: "turquoisebrightlight", rgb: "rgb(6, 227, 230)"},
+		{code: "3847", name: "tealgreendark", rgb: "rgb(52, 125, 117)"},
+		{code: "3848", name: "tealgreenmed", rgb: "rgb(85, 147, 146)"},
+		{code: "3849", name: "tealgreenlight", rgb: "rgb(82, 179, 164)"},
+		{code: "3850", name: "greenbrightdk", rgb: "rgb(55, 132, 119)"},
+		{code: "3851", name: "greenbrightlt", rgb: "rgb(73, 179, 161)"},
+		{code: "3852", name: "strawverydark", rgb: "rgb(205, 157, 55)"},
+		{code: "3853", name: "autumngolddk", rgb: "rgb(242, 151, 70)"},
+		{code: "3854", name: "autumngoldmed", rgb: "rgb(242, 175, 104)"},
+		{code: "3855", name: "autumngoldlt", rgb: "rgb(250, 211, 150)"},
+		{code: "3856", name: "mahoganyultvylt", rgb: "rgb(255, 211, 181)"},
+		{code: "3857", name: "rosewooddark", rgb: "rgb(104, 37, 26)"},
+		{code: "3858", name: "rosewoodmed", rgb: "rgb(150, 74, 63)"},
+		{code: "3859", name: "rosewoodlight", rgb: "rgb(186, 139, 124)"},
+		{code: "3860", name: "cocoa", rgb: "rgb(125, 93, 87)"},
+		{code: "3861", name: "cocoalight", rgb: "rgb(166, 136, 129)"},
+		{code: "3862", name: "mochabeigedark", rgb: "rgb(138, 110, 78)"},
+		{code: "3863", name: "mochabeigemed", rgb: "rgb(164, 131, 92)"},
+		{code: "3864", name: "mochabeigelight", rgb: "rgb(203, 182, 156)"},
+		{code: "3865", name: "winterwhite", rgb: "rgb(249, 247, 241)"},
+		{code: "3866", name: "mochabrnultvylt", rgb: "rgb(250, 246, 240)"},
+		{code: "400", name: "mahoganydark", rgb: "rgb(143, 67, 15)"},
+		{code: "402", name: "mahoganyvylt", rgb: "rgb(247, 167, 119)"},
+		{code: "407", name: "desertsandmed", rgb: "rgb(187, 129, 97)"},
+		{code: "413", name: "pewtergraydark", rgb: "rgb(86, 86, 86)"},
+		{code: "414", name: "steelgraydk", rgb: "rgb(140, 140, 140)"},
+		{code: "415", name: "pearlgray", rgb: "rgb(211, 211, 214)"},
+		{code: "420", name: "hazelnutbrowndk", rgb: "rgb(160, 112, 66)"},
+		{code: "422", name: "hazelnutbrownlt", rgb: "rgb(198, 159, 123)"},
+		{code: "433", name: "brownmed", rgb: "rgb(122, 69, 31)"},
+		{code: "434", name: "brownlight", rgb: "rgb(152, 94, 51)"},
+		{code: "435", name: "brownverylight", rgb: "rgb(184, 119, 72)"},
+		{code: "436", name: "tan", rgb: "rgb(203, 144, 81)"},
+		{code: "437", name: "tanlight", rgb: "rgb(228, 187, 142)"},
+		{code: "444", name: "lemondark", rgb: "rgb(255, 214, 0)"},
+		{code: "445", name: "lemonlight", rgb: "rgb(255, 251, 139)"},
+		{code: "451", name: "shellgraydark", rgb: "rgb(145, 123, 115)"},
+		{code: "452", name: "shellgraymed", rgb: "rgb(192, 179, 174)"},
+		{code: "453", name: "shellgraylight", rgb: "rgb(215, 206, 203)"},
+		{code: "469", name: "avocadogreen", rgb: "rgb(114, 132, 60)"},
+		{code: "470", name: "avocadogrnlt", rgb: "rgb(148, 171, 79)"},
+		{code: "471", name: "avocadogrnvlt", rgb: "rgb(174, 191, 121)"},
+		{code: "472", name: "avocadogrnult", rgb: "rgb(216, 228, 152)"},
+		{code: "498", name: "reddark", rgb: "rgb(167, 19, 43)"},
+		{code: "500", name: "bluegreenvydk", rgb: "rgb(4, 77, 51)"},
+		{code: "501", name: "bluegreendark", rgb: "rgb(57, 111, 82)"},
+		{code: "502", name: "bluegreen", rgb: "rgb(91, 144, 113)"},
+		{code: "503", name: "bluegreenmed", rgb: "rgb(123, 172, 148)"},
+		{code: "504", name: "bluegreenvylt", rgb: "rgb(196, 222, 204)"},
+		{code: "505", name: "jadegreen", rgb: "rgb(51, 131, 98)"},
+		{code: "517", name: "wedgewooddark", rgb: "rgb(59, 118, 143)"},
+		{code: "518", name: "wedgewoodlight", rgb: "rgb(79, 147, 167)"},
+		{code: "519", name: "skyblue", rgb: "rgb(126, 177, 200)"},
+		{code: "520", name: "ferngreendark", rgb: "rgb(102, 109, 79)"},
+		{code: "522", name: "ferngreen", rgb: "rgb(150, 158, 126)"},
+		{code: "523", name: "ferngreenlt", rgb: "rgb(171, 177, 151)"},
+		{code: "524", name: "ferngreenvylt", rgb: "rgb(196, 205, 172)"},
+		{code: "535", name: "ashgrayvylt", rgb: "rgb(99, 100, 88)"},
+		{code: "543", name: "beigebrownultvylt", rgb: "rgb(242, 227, 206)"},
+		{code: "550", name: "violetverydark", rgb: "rgb(92, 24, 78)"},
+		{code: "552", name: "violetmedium", rgb: "rgb(128, 58, 107)"},
+		{code: "553", name: "violet", rgb: "rgb(163, 99, 139)"},
+		{code: "554", name: "violetlight", rgb: "rgb(219, 179, 203)"},
+		{code: "561", name: "celadongreenvd", rgb: "rgb(44, 106, 69)"},
+		{code: "562", name: "jademedium", rgb: "rgb(83, 151, 106)"},
+		{code: "563", name: "jadelight", rgb: "rgb(143, 192, 152)"},
+		{code: "564", name: "jadeverylight", rgb: "rgb(167, 205, 175)"},
+		{code: "580", name: "mossgreendk", rgb: "rgb(136, 141, 51)"},
+		{code: "581", name: "mossgreen", rgb: "rgb(167, 174, 56)"},
+		{code: "597", name: "turquoise", rgb: "rgb(91, 163, 179)"},
+		{code: "598", name: "turquoiselight", rgb: "rgb(144, 195, 204)"},
+		{code: "600", name: "cranberryverydark", rgb: "rgb(205, 47, 99)"},
+		{code: "601", name: "cranberrydark", rgb: "rgb(209, 40, 106)"},
+		{code: "602", name: "cranberrymedium", rgb: "rgb(226, 72, 116)"},
+		{code: "603", name: "cranberry", rgb: "rgb(255, 164, 190)"},
+		{code: "604", name: "cranberrylight", rgb: "rgb(255, 176, 190)"},
+		{code: "605", name: "cranberryverylight", rgb: "rgb(255, 192, 205)"},
+		{code: "606", name: "orangeredbright", rgb: "rgb(250, 50, 3)"},
+		{code: "608", name: "burntorangebright", rgb: "rgb(253, 93, 53)"},
+		{code: "610", name: "drabbrowndk", rgb: "rgb(121, 96, 71)"},
+		{code: "611", name: "drabbrown", rgb: "rgb(150, 118, 86)"},
+		{code: "612", name: "drabbrownlt", rgb: "rgb(188, 154, 120)"},
+		{code: "613", name: "drabbrownvlt", rgb: "rgb(220, 196, 170)"},
+		{code: "632", name: "desertsandultvydk", rgb: "rgb(135, 85, 57)"},
+		{code: "640", name: "beigegrayvydk", rgb: "rgb(133, 123, 97)"},
+		{code: "642", name: "beigegraydark", rgb: "rgb(164, 152, 120)"},
+		{code: "644", name: "beigegraymed", rgb: "rgb(221, 216, 203)"},
+		{code: "645", name: "beavergrayvydk", rgb: "rgb(110, 101, 92)"},
+		{code: "646", name: "beavergraydk", rgb: "rgb(135, 125, 115)"},
+		{code: "647", name: "beavergraymed", rgb: "rgb(176, 166, 156)"},
+		{code: "648", name: "beavergraylt", rgb: "rgb(188, 180, 172)"},
+		{code: "666", name: "brightred", rgb: "rgb(227, 29, 66)"},
+		{code: "676", name: "oldgoldlt", rgb: "rgb(229, 206, 151)"},
+		{code: "677", name: "oldgoldvylt", rgb: "rgb(245, 236, 203)"},
+		{code: "680", name: "oldgolddark", rgb: "rgb(188, 141, 14)"},
+		{code: "699", name: "green", rgb: "rgb(5, 101, 23)"},
+		{code: "700", name: "greenbright", rgb: "rgb(7, 115, 27)"},
+		{code: "701", name: "greenlight", rgb: "rgb(63, 143, 41)"},
+		{code: "702", name: "kellygreen", rgb: "rgb(71, 167, 47)"},
+		{code: "703", name: "chartreuse", rgb: "rgb(123, 181, 71)"},
+		{code: "704", name: "chartreusebright", rgb: "rgb(158, 207, 52)"},
+		{code: "712", name: "cream", rgb: "rgb(255, 251, 239)"},
+		{code: "718", name: "plum", rgb: "rgb(156, 36, 98)"},
+		{code: "720", name: "orangespicedark", rgb: "rgb(229, 92, 31)"},
+		{code: "721", name: "orangespicemed", rgb: "rgb(242, 120, 66)"},
+		{code: "722", name: "orangespicelight", rgb: "rgb(247, 151, 111)"},
+		{code: "725", name: "topazmedlt", rgb: "rgb(255, 200, 64)"},
+		{code: "726", name: "topazlight", rgb: "rgb(253, 215, 85)"},
+		{code: "727", name: "topazvylt", rgb: "rgb(255, 241, 175)"},
+		{code: "728", name: "topaz", rgb: "rgb(228, 180, 104)"},
+		{code: "729", name: "oldgoldmedium", rgb: "rgb(208, 165, 62)"},
+		{code: "730", name: "olivegreenvdk", rgb: "rgb(130, 123, 48)"},
+		{code: "731", name: "olivegreendk", rgb: "rgb(147, 139, 55)"},
+		{code: "732", name: "olivegreen", rgb: "rgb(148, 140, 54)"},
+		{code: "733", name: "olivegreenmd", rgb: "rgb(188, 179, 76)"},
+		{code: "734", name: "olivegreenlt", rgb: "rgb(199, 192, 119)"},
+		{code: "738", name: "tanverylight", rgb: "rgb(236, 204, 158)"},
+		{code: "739", name: "tanultvylt", rgb: "rgb(248, 228, 200)"},
+		{code: "740", name: "tangerine", rgb: "rgb(255, 139, 0)"},
+		{code: "741", name: "tangerinemed", rgb: "rgb(255, 163, 43)"},
+		{code: "742", name: "tangerinelight", rgb: "rgb(255, 191, 87)"},
+		{code: "743", name: "yellowmed", rgb: "rgb(254, 211, 118)"},
+		{code: "744", name: "yellowpale", rgb: "rgb(255, 231, 147)"},
+		{code: "745", name: "yellowpalelight", rgb: "rgb(255, 233, 173)"},
+		{code: "746", name: "offwhite", rgb: "rgb(252, 252, 238)"},
+		{code: "747", name: "peacockbluevylt", rgb: "rgb(229, 252, 253)"},
+		{code: "754", name: "peachlight", rgb: "rgb(247, 203, 191)"},
+		{code: "758", name: "terracottavylt", rgb: "rgb(238, 170, 155)"},
+		{code: "760", name: "salmon", rgb: "rgb(245, 173, 173)"},
+		{code: "761", name: "salmonlight", rgb: "rgb(255, 201, 201)"},
+		{code: "762", name: "pearlgrayvylt", rgb: "rgb(236, 236, 236)"},
+		{code: "772", name: "yellowgreenvylt", rgb: "rgb(228, 236, 212)"},
+		{code: "775", name: "babyblueverylight", rgb: "rgb(217, 235, 241)"},
+		{code: "776", name: "pinkmedium", rgb: "rgb(252, 176, 185)"},
+		{code: "777", name: "raspberryverydark", rgb: "rgb(145, 53, 70)"},
+		{code: "778", name: "antiquemauvevylt", rgb: "rgb(223, 179, 187)"},
+		{code: "779", name: "cocoadark", rgb: "rgb(98, 75, 69)"},
+		{code: "780", name: "topazultravydk", rgb: "rgb(148, 99, 26)"},
+		{code: "781", name: "topazverydark", rgb: "rgb(162, 109, 32)"},
+		{code: "782", name: "topazdark", rgb: "rgb(174, 119, 32)"},
+		{code: "783", name: "topazmedium", rgb: "rgb(206, 145, 36)"},
+		{code: "791", name: "cornflowerbluevd", rgb: "rgb(70, 69, 99)"},
+		{code: "792", name: "cornflowerbluedark", rgb: "rgb(85, 91, 123)"},
+		{code: "793", name: "cornflowerbluemed", rgb: "rgb(112, 125, 162)"},
+		{code: "794", name: "cornflowerbluelight", rgb: "rgb(143, 156, 193)"},
+		{code: "796", name: "royalbluedark", rgb: "rgb(17, 65, 109)"},
+		{code: "797", name: "royalblue", rgb: "rgb(19, 71, 125)"},
+		{code: "798", name: "delftbluedark", rgb: "rgb(70, 106, 142)"},
+		{code: "799", name: "delftbluemedium", rgb: "rgb(116, 142, 182)"},
+		{code: "800", name: "delftbluepale", rgb: "rgb(192, 204, 222)"},
+		{code: "801", name: "coffeebrowndk", rgb: "rgb(101, 57, 25)"},
+		{code: "803", name: "babyblueultvydk", rgb: "rgb(44, 89, 124)"},
+		{code: "806", name: "peacockbluedark", rgb: "rgb(61, 149, 165)"},
+		{code: "807", name: "peacockblue", rgb: "rgb(100, 171, 186)"},
+		{code: "809", name: "delftblue", rgb: "rgb(148, 168, 198)"},
+		{code: "813", name: "bluelight", rgb: "rgb(161, 194, 215)"},
+		{code: "814", name: "garnetdark", rgb: "rgb(123, 0, 27)"},
+		{code: "815", name: "garnetmedium", rgb: "rgb(135, 7, 31)"},
+		{code: "816", name: "garnet", rgb: "rgb(151, 11, 35)"},
+		{code: "817", name: "coralredverydark", rgb: "rgb(187, 5, 31)"},
+		{code: "818", name: "babypink", rgb: "rgb(255, 223, 217)"},
+		{code: "819", name: "babypinklight", rgb: "rgb(255, 238, 235)"},
+		{code: "820", name: "royalblueverydark", rgb: "rgb(14, 54, 92)"},
+		{code: "822", name: "beigegraylight", rgb: "rgb(231, 226, 211)"},
+		{code: "823", name: "navybluedark", rgb: "rgb(33, 48, 99)"},
+		{code: "824", name: "blueverydark", rgb: "rgb(57, 105, 135)"},
+		{code: "825", name: "bluedark", rgb: "rgb(71, 129, 165)"},
+		{code: "826", name: "bluemedium", rgb: "rgb(107, 158, 191)"},
+		{code: "827", name: "blueverylight", rgb: "rgb(189, 221, 237)"},
+		{code: "828", name: "skybluevylt", rgb: "rgb(197, 232, 237)"},
+		{code: "829", name: "goldenolivevydk", rgb: "rgb(126, 107, 66)"},
+		{code: "830", name: "goldenolivedk", rgb: "rgb(141, 120, 75)"},
+		{code: "831", name: "goldenolivemd", rgb: "rgb(170, 143, 86)"},
+		{code: "832", name: "goldenolive", rgb: "rgb(189, 155, 81)"},
+		{code: "833", name: "goldenolivelt", rgb: "rgb(200, 171, 108)"},
+		{code: "834", name: "goldenolivevylt", rgb: "rgb(219, 190, 127)"},
+		{code: "838", name: "beigebrownvydk", rgb: "rgb(89, 73, 55)"},
+		{code: "839", name: "beigebrowndk", rgb: "rgb(103, 85, 65)"},
+		{code: "840", name: "beigebrownmed", rgb: "rgb(154, 124, 92)"},
+		{code: "841", name: "beigebrownlt", rgb: "rgb(182, 155, 126)"},
+		{code: "842", name: "beigebrownvylt", rgb: "rgb(209, 186, 161)"},
+		{code: "844", name: "beavergrayultdk", rgb: "rgb(72, 72, 72)"},
+		{code: "869", name: "hazelnutbrownvdk", rgb: "rgb(131, 94, 57)"},
+		{code: "890", name: "pistachiogrnultvd", rgb: "rgb(23, 73, 35)"},
+		{code: "891", name: "carnationdark", rgb: "rgb(255, 87, 115)"},
+		{code: "892", name: "carnationmedium", rgb: "rgb(255, 121, 140)"},
+		{code: "893", name: "carnationlight", rgb: "rgb(252, 144, 162)"},
+		{code: "894", name: "carnationverylight", rgb: "rgb(255, 178, 187)"},
+		{code: "895", name: "huntergreenvydk", rgb: "rgb(27, 83, 0)"},
+		{code: "898", name: "coffeebrownvydk", rgb: "rgb(73, 42, 19)"},
+		{code: "899", name: "rosemedium", rgb: "rgb(242, 118, 136)"},
+		{code: "900", name: "burntorangedark", rgb: "rgb(209, 88, 7)"},
+		{code: "902", name: "garnetverydark", rgb: "rgb(130, 38, 55)"},
+		{code: "904", name: "parrotgreenvdk", rgb: "rgb(85, 120, 34)"},
+		{code: "905", name: "parrotgreendk", rgb: "rgb(98, 138, 40)"},
+		{code: "906", name: "parrotgreenmd", rgb: "rgb(127, 179, 53)"},
+		{code: "907", name: "parrotgreenlt", rgb: "rgb(199, 230, 102)"},
+		{code: "909", name: "emeraldgreenvydk", rgb: "rgb(21, 111, 73)"},
+		{code: "910", name: "emeraldgreendark", rgb: "rgb(24, 126, 86)"},
+		{code: "911", name: "emeraldgreenmed", rgb: "rgb(24, 144, 101)"},
+		{code: "912", name: "emeraldgreenlt", rgb: "rgb(27, 157, 107)"},
+		{code: "913", name: "nilegreenmed", rgb: "rgb(109, 171, 119)"},
+		{code: "915", name: "plumdark", rgb: "rgb(130, 0, 67)"},
+		{code: "917", name: "plummedium", rgb: "rgb(155, 19, 89)"},
+		{code: "918", name: "redcopperdark", rgb: "rgb(130, 52, 10)"},
+		{code: "919", name: "redcopper", rgb: "rgb(166, 69, 16)"},
+		{code: "920", name: "coppermed", rgb: "rgb(172, 84, 20)"},
+		{code: "921", name: "copper", rgb: "rgb(198, 98, 24)"},
+		{code: "922", name: "copperlight", rgb: "rgb(226, 115, 35)"},
+		{code: "924", name: "graygreenvydark", rgb: "rgb(86, 106, 106)"},
+		{code: "926", name: "graygreenmed", rgb: "rgb(152, 174, 174)"},
+		{code: "927", name: "graygreenlight", rgb: "rgb(189, 203, 203)"},
+		{code: "928", name: "graygreenvylt", rgb: "rgb(221, 227, 227)"},
+		{code: "930", name: "antiquebluedark", rgb: "rgb(69, 92, 113)"},
+		{code: "931", name: "antiquebluemedium", rgb: "rgb(106, 133, 158)"},
+		{code: "932", name: "antiquebluelight", rgb: "rgb(162, 181, 198)"},
+		{code: "934", name: "avocadogrnblack", rgb: "rgb(49, 57, 25)"},
+		{code: "935", name: "avocadogreendk", rgb: "rgb(66, 77, 33)"},
+		{code: "936", name: "avocadogrnvdk", rgb: "rgb(76, 88, 38)"},
+		{code: "937", name: "avocadogreenmd", rgb: "rgb(98, 113, 51)"},
+		{code: "938", name: "coffeebrownultdk", rgb: "rgb(54, 31, 14)"},
+		{code: "939", name: "navyblueverydark", rgb: "rgb(27, 40, 83)"},
+		{code: "943", name: "greenbrightmd", rgb: "rgb(61, 147, 132)"},
+		{code: "945", name: "tawny", rgb: "rgb(251, 213, 187)"},
+		{code: "946", name: "burntorangemed", rgb: "rgb(235, 99, 7)"},
+		{code: "947", name: "burntorange", rgb: "rgb(255, 123, 77)"},
+		{code: "948", name: "peachverylight", rgb: "rgb(254, 231, 218)"},
+		{code: "950", name: "desertsandlight", rgb: "rgb(238, 211, 196)"},
+		{code: "951", name: "tawnylight", rgb: "rgb(255, 226, 207)"},
+		{code: "954", name: "nilegreen", rgb: "rgb(136, 186, 145)"},
+		{code: "955", name: "nilegreenlight", rgb: "rgb(162, 214, 173)"},
+		{code: "956", name: "geranium", rgb: "rgb(255, 145, 145)"},
+		{code: "957", name: "geraniumpale", rgb: "rgb(253, 181, 181)"},
+		{code: "958", name: "seagreendark", rgb: "rgb(62, 182, 161)"},
+		{code: "959", name: "seagreenmed", rgb: "rgb(89, 199, 180)"},
+		{code: "961", name: "dustyrosedark", rgb: "rgb(207, 115, 115)"},
+		{code: "962", name: "dustyrosemedium", rgb: "rgb(230, 138, 138)"},
+		{code: "963", name: "dustyroseultvylt", rgb: "rgb(255, 215, 215)"},
+		{code: "964", name: "seagreenlight", rgb: "rgb(169, 226, 216)"},
+		{code: "966", name: "jadeultravylt", rgb: "rgb(185, 215, 192)"},
+		{code: "967", name: "apricotverylight", rgb: "rgb(255, 222, 213)"},
+		{code: "970", name: "pumpkinlight", rgb: "rgb(247, 139, 19)"},
+		{code: "971", name: "pumpkin", rgb: "rgb(246, 127, 0)"},
+		{code: "972", name: "canarydeep", rgb: "rgb(255, 181, 21)"},
+		{code: "973", name: "canarybright", rgb: "rgb(255, 227, 0)"},
+		{code: "975", name: "goldenbrowndk", rgb: "rgb(145, 79, 18)"},
+		{code: "976", name: "goldenbrownmed", rgb: "rgb(194, 129, 66)"},
+		{code: "977", name: "goldenbrownlight", rgb: "rgb(220, 156, 86)"},
+		{code: "986", name: "forestgreenvydk", rgb: "rgb(64, 82, 48)"},
+		{code: "987", name: "forestgreendk", rgb: "rgb(88, 113, 65)"},
+		{code: "988", name: "forestgreenmed", rgb: "rgb(115, 139, 91)"},
+		{code: "989", name: "forestgreen", rgb: "rgb(141, 166, 117)"},
+		{code: "991", name: "aquamarinedk", rgb: "rgb(71, 123, 110)"},
+		{code: "992", name: "aquamarinelt", rgb: "rgb(111, 174, 159)"},
+		{code: "993", name: "aquamarinevylt", rgb: "rgb(144, 192, 180)"},
+		{code: "995", name: "electricbluedark", rgb: "rgb(38, 150, 182)"},
+		{code: "996", name: "electricbluemedium", rgb: "rgb(48, 194, 236)"},
+		{code: "B5200", name: "snowwhite", rgb: "rgb(255, 255, 255)"},
+		{code: "ECRU", name: "ecru", rgb: "rgb(240, 234, 218)"},
+		{code: "BLANC", name: "white", rgb: "rgb(252, 251, 248)"},
+	}
+}
+
+// anchorThreads are the anchor shades of a DMC to anchor conversion chart, each
+// takes its color (and name) from the DMC equivalent (not a measured anchor color).
+func anchorThreads() []flossThread {
+	return equivalentThreads([][]string{
+		{"1", "B5200"}, {"2", "BLANC"}, {"8", "353"}, {"9", "352"}, {"10", "351"}, {"11", "350"},
+		{"13", "817"}, {"22", "816"}, {"24", "776"}, {"27", "893"}, {"29", "891"}, {"31", "3708"},
+		{"35", "3705"}, {"36", "3326"}, {"43", "815"}, {"44", "814"}, {"46", "666"}, {"48", "818"},
+		{"49", "3689"}, {"50", "605"}, {"54", "956"}, {"60", "3688"}, {"65", "3685"}, {"72", "902"},
+		{"74", "3354"}, {"85", "3609"}, {"86", "3608"}, {"87", "3607"}, {"94", "917"}, {"97", "554"},
+		{"98", "553"}, {"99", "552"}, {"100", "327"}, {"101", "550"}, {"108", "210"}, {"109", "209"},
+		{"110", "208"}, {"118", "340"}, {"119", "333"}, {"120", "3747"}, {"121", "794"}, {"130", "809"},
+		{"131", "798"}, {"132", "797"}, {"133", "796"}, {"134", "820"}, {"136", "799"}, {"140", "3755"},
+		{"144", "800"}, {"150", "823"}, {"152", "939"}, {"161", "813"}, {"169", "806"},
+		{"185", "964"}, {"186", "959"}, {"187", "958"}, {"188", "3812"}, {"189", "991"}, {"205", "911"},
+		{"208", "563"}, {"209", "912"}, {"210", "562"}, {"212", "561"}, {"213", "504"}, {"214", "368"},
+		{"215", "320"}, {"216", "367"}, {"217", "319"}, {"226", "702"}, {"227", "701"}, {"228", "700"},
+		{"229", "910"}, {"230", "909"}, {"232", "452"}, {"234", "762"}, {"235", "414"}, {"236", "3799"},
+		{"238", "703"}, {"242", "989"}, {"243", "988"}, {"244", "987"}, {"246", "986"}, {"253", "772"},
+		{"255", "907"}, {"256", "704"}, {"257", "905"}, {"258", "904"}, {"263", "3362"}, {"264", "3348"},
+		{"266", "3347"}, {"267", "3346"}, {"268", "3345"}, {"269", "936"}, {"275", "746"}, {"277", "830"},
+		{"288", "445"}, {"289", "307"}, {"290", "444"}, {"292", "3078"}, {"293", "727"}, {"295", "726"},
+		{"297", "973"}, {"298", "972"}, {"300", "745"}, {"301", "744"}, {"302", "743"}, {"303", "742"},
+		{"304", "741"}, {"305", "725"}, {"307", "783"}, {"308", "782"}, {"309", "781"}, {"310", "434"},
+		{"316", "740"}, {"323", "722"}, {"324", "721"}, {"326", "720"}, {"328", "3341"}, {"329", "3340"},
+		{"330", "947"}, {"332", "946"}, {"333", "608"}, {"334", "606"}, {"337", "3778"}, {"339", "920"},
+		{"341", "918"}, {"342", "211"}, {"349", "301"}, {"351", "400"}, {"352", "300"}, {"355", "975"},
+		{"358", "433"}, {"359", "801"}, {"360", "898"}, {"361", "738"}, {"362", "437"}, {"366", "951"},
+		{"374", "420"}, {"378", "841"}, {"379", "840"}, {"381", "938"}, {"382", "3371"}, {"387", "ECRU"},
+		{"388", "842"}, {"390", "822"}, {"392", "642"}, {"393", "640"}, {"398", "415"}, {"399", "318"},
+		{"400", "317"}, {"401", "413"}, {"403", "310"}, {"410", "995"}, {"433", "996"}, {"842", "3013"},
+		{"843", "3012"}, {"850", "926"}, {"871", "3041"}, {"872", "3740"}, {"874", "834"}, {"878", "501"},
+		{"879", "500"}, {"881", "945"}, {"885", "739"}, {"886", "677"}, {"887", "3046"}, {"888", "3045"},
+		{"890", "729"}, {"891", "676"}, {"896", "3721"}, {"906", "829"}, {"920", "932"}, {"922", "930"},
+		{"923", "699"}, {"940", "792"}, {"970", "3726"}, {"978", "322"}, {"979", "312"}, {"1002", "977"},
+		{"1005", "498"}, {"1006", "304"}, {"1045", "436"}, {"1046", "435"}, {"9046", "321"},
+	})
+}
+
+// cosmoThreads are the cosmo shades of a cosmo to DMC conversion chart, each
+// takes its color (and name) from the DMC equivalent (not a measured cosmo color).
+func cosmoThreads() []flossThread {
+	return equivalentThreads([][]string{
+		{"100", "B5200"}, {"101", "819"}, {"102", "3713"}, {"103", "761"}, {"104", "760"},
+		{"105", "3712"}, {"106", "3328"}, {"107", "347"}, {"110", "BLANC"}, {"151", "762"},
+		{"152", "415"}, {"153", "318"}, {"154", "317"}, {"155", "413"}, {"211", "3325"}, {"212", "334"},
+		{"213", "322"}, {"214", "312"}, {"241", "321"}, {"242", "304"}, {"243", "815"}, {"245", "814"},
+		{"297", "745"}, {"298", "744"}, {"299", "743"}, {"300", "742"}, {"301", "741"}, {"317", "704"},
+		{"318", "703"}, {"319", "702"}, {"320", "701"}, {"364", "ECRU"}, {"382", "437"}, {"383", "436"},
+		{"384", "435"}, {"385", "434"}, {"386", "433"}, {"387", "801"}, {"388", "898"}, {"600", "310"},
+	})
+}
+
+// equivalentThreads are the threads of {code, DMC code} pairs, using the DMC floss color and name.
+func equivalentThreads(equivalents [][]string) []flossThread {
+	dmc := make(map[string]flossThread)
+	for _, thread := range dmcThreads() {
+		dmc[thread.code] = thread
+	}
+	var threads []flossThread
+	for _, pair := range equivalents {
+		if thread, ok := dmc[pair[1]]; ok {
+			threads = append(threads, flossThread{code: pair[0], name: thread.name, rgb: thread.rgb})
+		}
+	}
+	return threads
 }
@@ -0,0 +1,171 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"voidedtech.com/gxs/syntax"
+	"voidedtech.com/stock"
+)
+
+const (
+	diffContext = 3
+)
+
+type (
+	// diffOp is a line kept (' '), removed ('-') or added ('+'), at (0-based) lines of the old and new text.
+	diffOp struct {
+		kind byte
+		from int
+		to   int
+		text string
+	}
+)
+
+// NewFormatError creates a new pattern formatting error.
+func NewFormatError(message string) error {
+	return stock.NewBasicCategoryError("format", message)
+}
+
+// Format rewrites a pattern in the canonical layout (keeping comments), the
+// formatted pattern is checked to hold the same blocks and entries.
+func Format(b []byte) ([]byte, error) {
+	file, err := syntax.Parse(b)
+	if err != nil {
+		return nil, NewFormatError(err.Error())
+	}
+	formatted := syntax.Format(file)
+	check, err := syntax.Parse(formatted)
+	if err != nil || !sameBlocks(file, check) {
+		return nil, NewFormatError("formatting would change the pattern")
+	}
+	return formatted, nil
+}
+
+// blockEntries are the entries (as text) of every block, palettes are unordered.
+func blockEntries(file *syntax.File) []string {
+	var results []string
+	for _, block := range file.Blocks() {
+		var entries []string
+		for _, entry := range block.Entries() {
+			entries = append(entries, entry.String())
+		}
+		if block.Name == "palette" {
+			sort.Strings(entries)
+		}
+		results = append(results, fmt.Sprintf("%s\n%s", block.Name, strings.Join(entries, "\n")))
+	}
+	return results
+}
+
+func sameBlocks(a, b *syntax.File) bool {
+	return strings.Join(blockEntries(a), "\n") == strings.Join(blockEntries(b), "\n")
+}
+
+// Diff is a unified diff (nil when the same) of the text of a file before and after a change.
+func Diff(name string, before, after []byte) []byte {
+	ops := diffLines(toLines(before), toLines(after))
+	var hunks [][]diffOp
+	var hunk []diffOp
+	lastChange := -1
+	for idx, op := range ops {
+		if op.kind == ' ' {
+			continue
+		}
+		// changes share a hunk unless their context does not overlap
+		if hunk != nil && idx-lastChange-1 > 2*diffContext {
+			hunks = append(hunks, append(hunk, ops[lastChange+1:lastChange+1+diffContext]...))
+			hunk = nil
+		}
+		if hunk == nil {
+			start := idx - diffContext
+			if start < 0 {
+				start = 0
+			}
+			hunk = append(hunk, ops[start:idx]...)
+		} else {
+			hunk = append(hunk, ops[lastChange+1:idx]...)
+		}
+		hunk = append(hunk, op)
+		lastChange = idx
+	}
+	if hunk == nil {
+		return nil
+	}
+	stop := lastChange + 1 + diffContext
+	if stop > len(ops) {
+		stop = len(ops)
+	}
+	hunks = append(hunks, append(hunk, ops[lastChange+1:stop]...))
+	var out strings.Builder
+	out.WriteString(fmt.Sprintf("--- %s.orig\n+++ %s\n", name, name))
+	for _, hunk := range hunks {
+		oldCount, newCount := 0, 0
+		for _, op := range hunk {
+			if op.kind != '+' {
+				oldCount++
+			}
+			if op.kind != '-' {
+				newCount++
+			}
+		}
+		out.WriteString(fmt.Sprintf("@@ -%s +%s @@\n", hunkRange(hunk[0].from, oldCount), hunkRange(hunk[0].to, newCount)))
+		for _, op := range hunk {
+			out.WriteString(fmt.Sprintf("%c%s\n", op.kind, op.text))
+		}
+	}
+	return []byte(out.String())
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+func toLines(b []byte) []string {
+	text := strings.TrimSuffix(string(b), "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// diffLines is the edit script (via the longest common subsequence) from the old to the new lines.
+func diffLines(old, updated []string) []diffOp {
+	// common[i][j] is the longest common subsequence of old[i:] and updated[j:]
+	common := make([][]int, len(old)+1)
+	for i := range common {
+		common[i] = make([]int, len(updated)+1)
+	}
+	for i := len(old) - 1; i >= 0; i-- {
+		for j := len(updated) - 1; j >= 0; j-- {
+			if old[i] == updated[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else if common[i+1][j] >= common[i][j+1] {
+				common[i][j] = common[i+1][j]
+			} else {
+				common[i][j] = common[i][j+1]
+			}
+		}
+	}
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(old) || j < len(updated) {
+		switch {
+		case i < len(old) && j < len(updated) && old[i] == updated[j]:
+			ops = append(ops, diffOp{kind: ' ', from: i, to: j, text: old[i]})
+			i++
+			j++
+		case j == len(updated) || (i < len(old) && common[i+1][j] >= common[i][j+1]):
+			ops = append(ops, diffOp{kind: '-', from: i, to: j, text: old[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{kind: '+', from: i, to: j, text: updated[j]})
+			j++
+		}
+	}
+	return ops
+}
@@ -0,0 +1,121 @@
+package internal_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"voidedtech.com/gxs/internal"
+)
+
+func TestFormat(t *testing.T) {
+	formatted, err := internal.Format([]byte(`# the colors
+palette => {   # sorted by symbol
+  z => NONE
+	# red
+  x => red # full
+    y=>blue
+}
+
+
+mode => {
+	xstitch
+}
+pattern => {x}
+action => {   commit   } # done
+# the end`))
+	if err != nil {
+		t.Fatalf("valid format: %v", err)
+	}
+	expect := `# the colors
+palette => { # sorted by symbol
+    # red
+    x => red # full
+    y=>blue
+    z => NONE
+}
+
+mode => {xstitch}
+pattern => {
+    x
+}
+action => {commit} # done
+# the end
+`
+	if string(formatted) != expect {
+		t.Errorf("invalid format:\n%s", string(formatted))
+	}
+	again, err := internal.Format(formatted)
+	if err != nil || string(again) != string(formatted) {
+		t.Error("format should be stable")
+	}
+	if _, err := internal.Format([]byte("palette => {")); err == nil || err.Error() != "format: 1:1: unclosed block at block: 1" {
+		t.Errorf("invalid error: %v", err)
+	}
+}
+
+func TestFormatBuild(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "examples", "*.gxs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputs, err := filepath.Glob(filepath.Join("..", "tests", "inputs", "*.gxs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range append(files, inputs...) {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		formatted, err := internal.Format(raw)
+		if err != nil {
+			t.Errorf("unable to format %s: %v", file, err)
+			continue
+		}
+		original, pErr := internal.ParseWith(raw, internal.ParseOptions{File: file})
+		if pErr != nil {
+			t.Fatalf("unable to parse %s: %v", file, pErr.Error)
+		}
+		result, pErr := internal.ParseWith(formatted, internal.ParseOptions{File: file})
+		if pErr != nil {
+			t.Fatalf("unable to parse formatted %s: %v", file, pErr.Error)
+		}
+		for _, mode := range []string{internal.ASCIIMode, internal.HTMLMode, internal.SVGMode} {
+			expect, err := internal.Build(original, mode, &internal.Option{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual, err := internal.Build(result, mode, &internal.Option{})
+			if err != nil || string(actual) != string(expect) {
+				t.Errorf("formatting %s changed the %s output", file, mode)
+			}
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	if internal.Diff("same.gxs", []byte("a\nb\n"), []byte("a\nb\n")) != nil {
+		t.Error("no diff expected")
+	}
+	before := []byte("1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n")
+	after := []byte("1\ntwo\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n")
+	expect := `--- test.gxs.orig
++++ test.gxs
+@@ -1,5 +1,5 @@
+ 1
+-2
++two
+ 3
+ 4
+ 5
+@@ -10,3 +10,4 @@
+ 10
+ 11
+ 12
++13
+`
+	if diff := string(internal.Diff("test.gxs", before, after)); diff != expect {
+		t.Errorf("invalid diff:\n%s", diff)
+	}
+}
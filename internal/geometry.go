@@ -0,0 +1,260 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+)
+
+type (
+	// segment is a line within a cell, in cell units (0 to 1 from the top-left).
+	segment struct {
+		x1 float64
+		y1 float64
+		x2 float64
+		y2 float64
+	}
+	legendEntry struct {
+		color  colorMap
+		text   string
+		symbol string
+	}
+)
+
+const (
+	stitchInset = 0.15
+	cornerTL    = "tl"
+	cornerTR    = "tr"
+	cornerBL    = "bl"
+	cornerBR    = "br"
+	// the center of a cell is covered by any half (or larger) stitch
+	cellCenter = "center"
+)
+
+var (
+	halfTRBL = segment{x1: 1 - stitchInset, y1: stitchInset, x2: stitchInset, y2: 1 - stitchInset}
+	halfTLBR = segment{x1: stitchInset, y1: stitchInset, x2: 1 - stitchInset, y2: 1 - stitchInset}
+	quarters = map[string]segment{
+		cornerTL: {x1: stitchInset, y1: stitchInset, x2: 0.5, y2: 0.5},
+		cornerTR: {x1: 1 - stitchInset, y1: stitchInset, x2: 0.5, y2: 0.5},
+		cornerBL: {x1: stitchInset, y1: 1 - stitchInset, x2: 0.5, y2: 0.5},
+		cornerBR: {x1: 1 - stitchInset, y1: 1 - stitchInset, x2: 0.5, y2: 0.5},
+	}
+)
+
+func stitchSegments(mode string) []segment {
+	switch mode {
+	case isXStitch:
+		return []segment{halfTLBR, halfTRBL}
+	case isHalfTRBL:
+		return []segment{halfTRBL}
+	case isHalfTLBR:
+		return []segment{halfTLBR}
+	case isQuarterTL:
+		return []segment{quarters[cornerTL]}
+	case isQuarterTR:
+		return []segment{quarters[cornerTR]}
+	case isQuarterBL:
+		return []segment{quarters[cornerBL]}
+	case isQuarterBR:
+		return []segment{quarters[cornerBR]}
+	case isThreeQuarterTL:
+		return []segment{halfTRBL, quarters[cornerTL]}
+	case isThreeQuarterTR:
+		return []segment{halfTLBR, quarters[cornerTR]}
+	case isThreeQuarterBL:
+		return []segment{halfTLBR, quarters[cornerBL]}
+	case isThreeQuarterBR:
+		return []segment{halfTRBL, quarters[cornerBR]}
+	case isTopEdge:
+		return []segment{{x1: 0, y1: 0, x2: 1, y2: 0}}
+	case isBottomEdge:
+		return []segment{{x1: 0, y1: 1, x2: 1, y2: 1}}
+	case isLeftEdge:
+		return []segment{{x1: 0, y1: 0, x2: 0, y2: 1}}
+	case isRightEdge:
+		return []segment{{x1: 1, y1: 0, x2: 1, y2: 1}}
+	case isHorizontalLine:
+		return []segment{{x1: 0, y1: 0.5, x2: 1, y2: 0.5}}
+	case isVerticalLine:
+		return []segment{{x1: 0.5, y1: 0, x2: 0.5, y2: 1}}
+	case isTopLeftBottomRight:
+		return []segment{{x1: 0, y1: 0, x2: 1, y2: 1}}
+	case isTopRightBottomLeft:
+		return []segment{{x1: 1, y1: 0, x2: 0, y2: 1}}
+	}
+	return nil
+}
+
+func isBackstitch(mode string) bool {
+	return mode != isXStitch && !isFractional(mode) && !isPoint(mode)
+}
+
+func isPoint(mode string) bool {
+	return mode == isKnot || mode == isBead
+}
+
+// pointGradients draws the part of any knots/beads at the corners of a cell, an
+// intersection is shared by (up to) 4 cells which each draw a quarter of it.
+func pointGradients(e entry, x, y int) []string {
+	var results []string
+	for _, c := range e.cells {
+		corner := ""
+		switch {
+		case c.x == x && c.y == y:
+			corner = "top left"
+		case c.x == x+1 && c.y == y:
+			corner = "top right"
+		case c.x == x && c.y == y+1:
+			corner = "bottom left"
+		case c.x == x+1 && c.y == y+1:
+			corner = "bottom right"
+		default:
+			continue
+		}
+		if e.mode == isBead {
+			results = append(results, fmt.Sprintf("radial-gradient(circle at %s, transparent 1.5px, %s 1.5px, %s 3.5px, transparent 3.5px)", corner, e.color, e.color))
+		} else {
+			results = append(results, fmt.Sprintf("radial-gradient(circle at %s, %s 3px, transparent 3px)", corner, e.color))
+		}
+	}
+	return results
+}
+
+func isFractional(mode string) bool {
+	return fractionCoverage(mode) != nil
+}
+
+// fractionCoverage is the set of cell corners (and center) a fractional stitch covers.
+func fractionCoverage(mode string) []string {
+	switch mode {
+	case isHalfTRBL:
+		return []string{cornerTR, cornerBL, cellCenter}
+	case isHalfTLBR:
+		return []string{cornerTL, cornerBR, cellCenter}
+	case isQuarterTL:
+		return []string{cornerTL}
+	case isQuarterTR:
+		return []string{cornerTR}
+	case isQuarterBL:
+		return []string{cornerBL}
+	case isQuarterBR:
+		return []string{cornerBR}
+	case isThreeQuarterTL:
+		return []string{cornerTL, cornerTR, cornerBL, cellCenter}
+	case isThreeQuarterTR:
+		return []string{cornerTL, cornerTR, cornerBR, cellCenter}
+	case isThreeQuarterBL:
+		return []string{cornerTL, cornerBL, cornerBR, cellCenter}
+	case isThreeQuarterBR:
+		return []string{cornerTR, cornerBL, cornerBR, cellCenter}
+	}
+	return nil
+}
+
+// stitchFraction is the portion of a full cross stitch a stitch mode uses.
+func stitchFraction(mode string) float64 {
+	switch mode {
+	case isXStitch:
+		return 1
+	case isHalfTRBL, isHalfTLBR:
+		return 0.5
+	case isQuarterTL, isQuarterTR, isQuarterBL, isQuarterBR:
+		return 0.25
+	case isThreeQuarterTL, isThreeQuarterTR, isThreeQuarterBL, isThreeQuarterBR:
+		return 0.75
+	}
+	return 0
+}
+
+// checkFractions verifies fractional stitches within a cell do not overlap (e.g. 2 quarters
+// in different corners may share a cell, 2 halves may not).
+func checkFractions(entries []entry) error {
+	covered := make(map[string]bool)
+	for _, e := range entries {
+		for _, part := range fractionCoverage(e.mode) {
+			if covered[part] {
+				return NewTemplateError("fractional stitches overlap within a cell")
+			}
+			covered[part] = true
+		}
+	}
+	return nil
+}
+
+func fractionGradients(mode, color string) []string {
+	quarter := func(direction string) string {
+		return fmt.Sprintf("linear-gradient(%s, %s 25%%, transparent 25%%)", direction, color)
+	}
+	half := func(direction string) string {
+		return fmt.Sprintf("linear-gradient(%s, transparent 42%%, %s 42%%, %s 58%%, transparent 58%%)", direction, color, color)
+	}
+	// a gradient's color bands run perpendicular to its direction
+	trbl := half("to bottom right")
+	tlbr := half("to bottom left")
+	switch mode {
+	case isHalfTRBL:
+		return []string{trbl}
+	case isHalfTLBR:
+		return []string{tlbr}
+	case isQuarterTL:
+		return []string{quarter("to bottom right")}
+	case isQuarterTR:
+		return []string{quarter("to bottom left")}
+	case isQuarterBL:
+		return []string{quarter("to top right")}
+	case isQuarterBR:
+		return []string{quarter("to top left")}
+	case isThreeQuarterTL:
+		return []string{trbl, quarter("to bottom right")}
+	case isThreeQuarterTR:
+		return []string{tlbr, quarter("to bottom left")}
+	case isThreeQuarterBL:
+		return []string{tlbr, quarter("to top right")}
+	case isThreeQuarterBR:
+		return []string{trbl, quarter("to top left")}
+	}
+	return nil
+}
+
+func (p Pattern) countMode(color, mode string) int {
+	count := 0
+	for _, e := range p.entries {
+		if e.color == color && e.mode == mode {
+			count += len(e.cells)
+		}
+	}
+	return count
+}
+
+func (p Pattern) cellIndex() map[cell][]entry {
+	index := make(map[cell][]entry)
+	for _, e := range p.entries {
+		for _, c := range e.cells {
+			index[c] = append(index[c], e)
+		}
+	}
+	return index
+}
+
+func (p Pattern) legend(symbols map[string]string) []legendEntry {
+	var colors []colorMap
+	colors = append(colors, p.colors...)
+	sort.Slice(colors, func(i, j int) bool {
+		if colors[i].input == colors[j].input {
+			return colors[i].count < colors[j].count
+		}
+		return colors[i].input < colors[j].input
+	})
+	var result []legendEntry
+	for _, mapped := range colors {
+		symbol := symbols[mapped.output]
+		for idx, line := range mapped.chartLines(symbol) {
+			entry := legendEntry{color: mapped, text: line}
+			if idx == 0 && mapped.count > 0 {
+				entry.symbol = symbol
+			}
+			result = append(result, entry)
+		}
+	}
+	return result
+}
@@ -0,0 +1,116 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	gridCount = 10
+	// center markers are drawn in a cell sized border outside of the grid
+	markerSize = 0.8
+)
+
+type (
+	// HTMLMarker is a center marker (arrow) drawn around the HTML grid.
+	HTMLMarker struct {
+		Points string
+	}
+	// markerArrow points at a center row/column from outside the grid, the
+	// tip is at a position along the edge with the base towards the outside.
+	markerArrow struct {
+		tipX  float64
+		tipY  float64
+		baseX float64
+		baseY float64
+	}
+)
+
+// isAxisLabel indicates if a row/column is labeled (all of them or the first and every 10th).
+func isAxisLabel(idx int, opts *Option) bool {
+	if !opts.AxisTens() {
+		return true
+	}
+	return idx == 1 || idx%gridCount == 0
+}
+
+// boldLines are the grid intersections (between cells) which get a heavier line.
+func boldLines(size int, opts *Option) []int {
+	if !opts.BoldGridlines() {
+		return nil
+	}
+	var lines []int
+	for idx := gridCount; idx < size; idx += gridCount {
+		lines = append(lines, idx)
+	}
+	return lines
+}
+
+// center is the center column/row in cell units (0,0 is the top-left of the grid).
+func (p Pattern) center() (float64, float64) {
+	// center stitches are 1-based, the middle of stitch N is at N-0.5
+	return centerStitch(p.width) - 0.5, centerStitch(p.height) - 0.5
+}
+
+// centerArrows are the arrows (in cell units, 0,0 is the top-left of the
+// grid) marking the center column (x) and row (y) on all 4 edges of a width
+// by height grid, a center outside of the grid is not marked.
+func centerArrows(x, y, width, height float64, opts *Option) []markerArrow {
+	if !opts.CenterMarkers() {
+		return nil
+	}
+	var arrows []markerArrow
+	if x >= 0 && x <= width {
+		arrows = append(arrows,
+			markerArrow{tipX: x, tipY: 0, baseX: x, baseY: -markerSize},
+			markerArrow{tipX: x, tipY: height, baseX: x, baseY: height + markerSize})
+	}
+	if y >= 0 && y <= height {
+		arrows = append(arrows,
+			markerArrow{tipX: 0, tipY: y, baseX: -markerSize, baseY: y},
+			markerArrow{tipX: width, tipY: y, baseX: width + markerSize, baseY: y})
+	}
+	return arrows
+}
+
+// polygon is the triangle of the arrow, scaled and moved to the output coordinates.
+func (m markerArrow) polygon(scale, originX, originY float64) [][]float64 {
+	half := markerSize / 2
+	dx := 0.0
+	dy := 0.0
+	if m.tipX == m.baseX {
+		dx = half
+	} else {
+		dy = half
+	}
+	points := [][]float64{{m.tipX, m.tipY}, {m.baseX - dx, m.baseY - dy}, {m.baseX + dx, m.baseY + dy}}
+	for _, point := range points {
+		point[0] = originX + point[0]*scale
+		point[1] = originY + point[1]*scale
+	}
+	return points
+}
+
+func (p Pattern) htmlGrid(opts *Option) ([]HTMLLine, []HTMLMarker) {
+	// grid line N sits within the gap after cell N (the first row/column are labels)
+	position := func(v int) float64 {
+		return float64(v+1)*htmlCellPitch - 0.5
+	}
+	var lines []HTMLLine
+	for _, x := range boldLines(p.width, opts) {
+		lines = append(lines, HTMLLine{X1: position(x), Y1: position(0), X2: position(x), Y2: position(p.height), Color: "black"})
+	}
+	for _, y := range boldLines(p.height, opts) {
+		lines = append(lines, HTMLLine{X1: position(0), Y1: position(y), X2: position(p.width), Y2: position(y), Color: "black"})
+	}
+	var markers []HTMLMarker
+	x, y := p.center()
+	for _, arrow := range centerArrows(x, y, float64(p.width), float64(p.height), opts) {
+		var points []string
+		for _, point := range arrow.polygon(htmlCellPitch, htmlCellPitch-0.5, htmlCellPitch-0.5) {
+			points = append(points, fmt.Sprintf("%s,%s", svgFloat(point[0]), svgFloat(point[1])))
+		}
+		markers = append(markers, HTMLMarker{Points: strings.Join(points, " ")})
+	}
+	return lines, markers
+}
@@ -0,0 +1,170 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	// gif support for importing
+	_ "image/gif"
+	// jpeg support for importing
+	_ "image/jpeg"
+	// png support for importing
+	_ "image/png"
+	"sort"
+	"strings"
+
+	"voidedtech.com/stock"
+)
+
+const (
+	importSymbols = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	importNone    = "-"
+	importIndent  = "    "
+)
+
+type (
+	importCell struct {
+		lab         labColor
+		transparent bool
+		floss       int
+	}
+)
+
+// NewImportError creates a new image import error.
+func NewImportError(message string) error {
+	return stock.NewBasicCategoryError("import", message)
+}
+
+func averageRegion(img image.Image, region image.Rectangle) (color.RGBA, bool) {
+	var r, g, b, a, count uint64
+	for y := region.Min.Y; y < region.Max.Y; y++ {
+		for x := region.Min.X; x < region.Max.X; x++ {
+			// values are alpha-premultiplied, summing them weights by alpha
+			pr, pg, pb, pa := img.At(x, y).RGBA()
+			r += uint64(pr)
+			g += uint64(pg)
+			b += uint64(pb)
+			a += uint64(pa)
+			count++
+		}
+	}
+	if count == 0 || a/count < 0x8000 {
+		return color.RGBA{}, true
+	}
+	return color.RGBA{R: uint8((r * 0xffff / a) >> 8), G: uint8((g * 0xffff / a) >> 8), B: uint8((b * 0xffff / a) >> 8), A: 255}, false
+}
+
+// Import converts an image (png, jpeg or gif) into a gxs pattern of width by height
+// stitches, using at most maxColors floss colors. A height of 0 keeps the aspect ratio.
+func Import(b []byte, width, height, maxColors int) ([]byte, error) {
+	img, _, err := image.Decode(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	if maxColors < 1 || maxColors > len(importSymbols) {
+		return nil, NewImportError(fmt.Sprintf("colors must be between 1 and %d", len(importSymbols)))
+	}
+	bounds := img.Bounds()
+	if bounds.Dx() == 0 || bounds.Dy() == 0 {
+		return nil, NewImportError("empty image")
+	}
+	if width < 1 {
+		return nil, NewImportError("invalid width")
+	}
+	if height == 0 {
+		height = (width*bounds.Dy() + bounds.Dx()/2) / bounds.Dx()
+		if height < 1 {
+			height = 1
+		}
+	}
+	if height < 1 {
+		return nil, NewImportError("invalid height")
+	}
+
+	table := newFlossCatalog().tables[dmcBrand]
+	var all []int
+	for idx := range table {
+		all = append(all, idx)
+	}
+	counts := make(map[int]int)
+	cells := make([][]importCell, height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			region := image.Rect(
+				bounds.Min.X+x*bounds.Dx()/width,
+				bounds.Min.Y+y*bounds.Dy()/height,
+				bounds.Min.X+(x+1)*bounds.Dx()/width,
+				bounds.Min.Y+(y+1)*bounds.Dy()/height)
+			if region.Dx() == 0 {
+				region.Max.X = region.Min.X + 1
+			}
+			if region.Dy() == 0 {
+				region.Max.Y = region.Min.Y + 1
+			}
+			rgb, transparent := averageRegion(img, region)
+			c := importCell{lab: toLab(rgb), transparent: transparent, floss: -1}
+			if !transparent {
+				c.floss, _ = nearestFloss(table, all, c.lab)
+				counts[c.floss]++
+			}
+			cells[y] = append(cells[y], c)
+		}
+	}
+
+	var used []int
+	for idx := range counts {
+		used = append(used, idx)
+	}
+	sort.Slice(used, func(i, j int) bool {
+		if counts[used[i]] == counts[used[j]] {
+			return used[i] < used[j]
+		}
+		return counts[used[i]] > counts[used[j]]
+	})
+	if len(used) > maxColors {
+		used = used[0:maxColors]
+	}
+	symbols := make(map[int]string)
+	for idx, floss := range used {
+		symbols[floss] = string(importSymbols[idx])
+	}
+
+	hasNone := false
+	var rows []string
+	for _, row := range cells {
+		var line strings.Builder
+		for _, c := range row {
+			if c.transparent {
+				hasNone = true
+				line.WriteString(importNone)
+				continue
+			}
+			floss := c.floss
+			if _, ok := symbols[floss]; !ok {
+				floss, _ = nearestFloss(table, used, c.lab)
+			}
+			line.WriteString(symbols[floss])
+		}
+		rows = append(rows, line.String())
+	}
+
+	var out bytes.Buffer
+	out.WriteString(fmt.Sprintf("# imported by gxs: %dx%d stitches, %d colors\n", width, height, len(used)))
+	out.WriteString("palette => {\n")
+	for _, floss := range used {
+		out.WriteString(fmt.Sprintf("%s%s => %s%s%s\n", importIndent, symbols[floss], dmcBrand, brandSeparator, table[floss].thread.code))
+	}
+	if hasNone {
+		out.WriteString(fmt.Sprintf("%s%s => %s\n", importIndent, importNone, noColor))
+	}
+	out.WriteString("}\n")
+	out.WriteString(fmt.Sprintf("mode => {%s}\n", isXStitch))
+	out.WriteString("pattern => {\n")
+	for _, row := range rows {
+		out.WriteString(fmt.Sprintf("%s%s\n", importIndent, row))
+	}
+	out.WriteString("}\n")
+	out.WriteString("action => {commit}\n")
+	return out.Bytes(), nil
+}
@@ -0,0 +1,75 @@
+package internal_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"voidedtech.com/gxs/internal"
+)
+
+func testImage(t *testing.T) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 40; x++ {
+			c := color.RGBA{A: 255}
+			switch {
+			case x < 10:
+				c = color.RGBA{R: 199, G: 43, B: 59, A: 255}
+			case x < 20:
+				c = color.RGBA{R: 250, G: 250, B: 250, A: 255}
+			case x < 30:
+				c = color.RGBA{R: 20, G: 60, B: 200, A: 255}
+			case x < 35:
+				c = color.RGBA{}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	var b bytes.Buffer
+	if err := png.Encode(&b, img); err != nil {
+		t.Error("unable to encode")
+	}
+	return b.Bytes()
+}
+
+func TestImport(t *testing.T) {
+	b, err := internal.Import(testImage(t), 8, 0, 16)
+	if err != nil {
+		t.Error("valid import")
+	}
+	text := string(b)
+	if !strings.Contains(text, "# imported by gxs: 8x4 stitches, 4 colors") || !strings.Contains(text, "- => NONE") || !strings.Contains(text, "=> dmc:321\n") || !strings.Contains(text, "=> dmc:310\n") {
+		t.Error("invalid pattern")
+		t.Error(text)
+	}
+	if _, pErr := internal.Parse(b); pErr != nil {
+		t.Error("import should parse")
+	}
+	b, err = internal.Import(testImage(t), 8, 2, 2)
+	if err != nil {
+		t.Error("valid import")
+	}
+	if !strings.Contains(string(b), "8x2 stitches, 2 colors") || strings.Contains(string(b), "\n    c => ") {
+		t.Error("invalid color limit")
+		t.Error(string(b))
+	}
+	if _, pErr := internal.Parse(b); pErr != nil {
+		t.Error("import should parse")
+	}
+}
+
+func TestImportErrors(t *testing.T) {
+	if _, err := internal.Import([]byte("abc"), 8, 0, 16); err == nil {
+		t.Error("invalid image")
+	}
+	if _, err := internal.Import(testImage(t), 0, 0, 16); err == nil || err.Error() != "import: invalid width" {
+		t.Error("invalid width")
+	}
+	if _, err := internal.Import(testImage(t), 8, 0, 0); err == nil || err.Error() != "import: colors must be between 1 and 62" {
+		t.Error("invalid colors")
+	}
+}
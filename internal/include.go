@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	includeBlock = "include"
+	includeChain = " -> "
+	stdinName    = "<stdin>"
+)
+
+type (
+	// ParseOptions configure parsing, mainly how include blocks are resolved.
+	ParseOptions struct {
+		// File is the path of the pattern being parsed, includes are relative to its
+		// directory (the working directory when not set).
+		File string
+		// IncludePaths are searched (in order) for includes not found relative to the including file.
+		IncludePaths []string
+		// FS serves the includes (else the os filesystem), paths within it are slash separated.
+		FS fs.FS
+		// MaxErrors is the number of errors collected before parsing stops (default 10).
+		MaxErrors int
+	}
+	// includeFrame is a file being parsed and the frame which included it.
+	includeFrame struct {
+		path   string
+		parent int
+	}
+)
+
+func (o ParseOptions) dir(file string) string {
+	if file == "" {
+		return "."
+	}
+	if o.FS != nil {
+		return path.Dir(file)
+	}
+	return filepath.Dir(file)
+}
+
+func (o ParseOptions) join(dir, name string) string {
+	if o.FS != nil {
+		return path.Join(dir, name)
+	}
+	return filepath.Join(dir, name)
+}
+
+func (o ParseOptions) read(name string) ([]byte, error) {
+	if o.FS != nil {
+		return fs.ReadFile(o.FS, name)
+	}
+	return os.ReadFile(name)
+}
+
+// key identifies a file (for cycle detection) regardless of how it was reached.
+func (o ParseOptions) key(name string) string {
+	if o.FS != nil {
+		return path.Clean(name)
+	}
+	if abs, err := filepath.Abs(name); err == nil {
+		return abs
+	}
+	return filepath.Clean(name)
+}
+
+// resolve finds an include relative to the including file, then within the include paths.
+func (o ParseOptions) resolve(name, from string) (string, []byte, error) {
+	var candidates []string
+	switch {
+	case o.FS != nil && strings.HasPrefix(name, "/"):
+		candidates = []string{strings.TrimPrefix(name, "/")}
+	case o.FS == nil && filepath.IsAbs(name):
+		candidates = []string{name}
+	default:
+		candidates = append(candidates, o.join(o.dir(from), name))
+		for _, dir := range o.IncludePaths {
+			candidates = append(candidates, o.join(dir, name))
+		}
+	}
+	for _, candidate := range candidates {
+		data, err := o.read(candidate)
+		if err == nil {
+			return candidate, data, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", nil, err
+		}
+	}
+	return "", nil, NewParsingError(fmt.Sprintf("include not found: %s", name))
+}
+
+// cycle is the include chain (outermost first) when a file is already being included by the frame.
+func (o ParseOptions) cycle(frames []includeFrame, frame int, file string) (string, bool) {
+	var chain []string
+	found := false
+	for idx := frame; idx >= 0; idx = frames[idx].parent {
+		name := frames[idx].path
+		if name == "" {
+			name = stdinName
+		} else if o.key(name) == o.key(file) {
+			found = true
+		}
+		chain = append([]string{name}, chain...)
+		if idx == 0 {
+			break
+		}
+	}
+	if !found {
+		return "", false
+	}
+	return strings.Join(append(chain, file), includeChain), true
+}
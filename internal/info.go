@@ -0,0 +1,56 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+)
+
+const (
+	inchesToCentimeters = 2.54
+	defaultMargin       = 3.0
+)
+
+type (
+	dimension struct {
+		width  float64
+		height float64
+	}
+)
+
+func (d dimension) String() string {
+	return fmt.Sprintf("%.2fin x %.2fin (%.2fcm x %.2fcm)", d.width, d.height, d.width*inchesToCentimeters, d.height*inchesToCentimeters)
+}
+
+func centerStitch(size int) float64 {
+	// even sized axes center between two stitches (e.g. 20.5)
+	return float64(size+1) / 2
+}
+
+func (p Pattern) finishedSize(opts *Option) dimension {
+	count := opts.FabricCount()
+	return dimension{width: float64(p.width) / count, height: float64(p.height) / count}
+}
+
+func (p Pattern) fabricCut(opts *Option) dimension {
+	finished := p.finishedSize(opts)
+	margin := 2 * opts.Margin()
+	return dimension{width: finished.width + margin, height: finished.height + margin}
+}
+
+func (p Pattern) info(opts *Option) []string {
+	return []string{
+		fmt.Sprintf("design: %dx%d stitches", p.width, p.height),
+		fmt.Sprintf("fabric: %s", opts.Fabric()),
+		fmt.Sprintf("finished size: %s", p.finishedSize(opts)),
+		fmt.Sprintf("fabric cut (%.2fin margin): %s", opts.Margin(), p.fabricCut(opts)),
+		fmt.Sprintf("center stitch: x=%g, y=%g", centerStitch(p.width), centerStitch(p.height)),
+	}
+}
+
+func info(p Pattern, opts *Option) ([]byte, error) {
+	var b bytes.Buffer
+	for _, line := range p.info(opts) {
+		b.WriteString(fmt.Sprintf("%s\n", line))
+	}
+	return b.Bytes(), nil
+}
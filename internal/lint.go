@@ -0,0 +1,346 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"voidedtech.com/gxs/syntax"
+	"voidedtech.com/stock"
+)
+
+const (
+	lintUnusedSymbol   = "unused-symbol"
+	lintRawColor       = "raw-color"
+	lintHiddenStitch   = "hidden-stitch"
+	lintStitchLine     = "stitch-line"
+	lintOffsetOverflow = "offset-overflow"
+)
+
+type (
+	// LintIssue is a check which fired at a position within a pattern.
+	LintIssue struct {
+		Check    string
+		Position Position
+		Message  string
+	}
+	lintInput struct {
+		blocks  []patternBlock
+		actions []patternAction
+		pattern Pattern
+	}
+	paletteSymbol struct {
+		symbol   string
+		position Position
+		used     bool
+	}
+	// layerBounds are the (1-based, inclusive) cells covered by a layer.
+	layerBounds struct {
+		minX int
+		minY int
+		maxX int
+		maxY int
+		set  bool
+	}
+)
+
+var (
+	lintChecks = map[string]func(lintInput) []LintIssue{
+		// palette symbols never used by a committed pattern, motif or backstitch
+		lintUnusedSymbol: unusedSymbols,
+		// palette colors matching no floss (passed through as given)
+		lintRawColor: rawColors,
+		// xstitches covered by an xstitch of a later layer
+		lintHiddenStitch: hiddenStitches,
+		// stitches and lines within the same cell (an ASCII warning)
+		lintStitchLine: stitchLines,
+		// offset layers reaching past every other layer
+		lintOffsetOverflow: offsetOverflows,
+	}
+)
+
+// NewLintError creates a new pattern lint error.
+func NewLintError(message string) error {
+	return stock.NewBasicCategoryError("lint", message)
+}
+
+// LintChecks are the names of every lint check.
+func LintChecks() []string {
+	var names []string
+	for name := range lintChecks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateLintCheck errors when the name is not a lint check.
+func ValidateLintCheck(name string) error {
+	if _, ok := lintChecks[name]; !ok {
+		return NewLintError(fmt.Sprintf("unknown check: %s (checks: %s)", name, strings.Join(LintChecks(), ", ")))
+	}
+	return nil
+}
+
+func (i LintIssue) String() string {
+	return fmt.Sprintf("%s: %s (%s)", i.Position, i.Message, i.Check)
+}
+
+// Lint runs every check (except those disabled) over a pattern (which must parse), issues are in source order.
+func Lint(b []byte, opts ParseOptions, disabled []string) ([]LintIssue, *ParserError) {
+	blocks, actions, pattern, err := parse(b, opts)
+	if err != nil {
+		return nil, err
+	}
+	skip := make(map[string]bool)
+	for _, name := range disabled {
+		skip[name] = true
+	}
+	input := lintInput{blocks: blocks, actions: actions, pattern: pattern}
+	var issues []LintIssue
+	for _, name := range LintChecks() {
+		if !skip[name] {
+			issues = append(issues, lintChecks[name](input)...)
+		}
+	}
+	files := make(map[string]int)
+	for _, block := range blocks {
+		if _, ok := files[block.start.File]; !ok {
+			files[block.start.File] = len(files)
+		}
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i].Position, issues[j].Position
+		if a.File != b.File {
+			return files[a.File] < files[b.File]
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return issues, nil
+}
+
+func unusedSymbols(input lintInput) []LintIssue {
+	var all []*paletteSymbol
+	palette := make(map[string]*paletteSymbol)
+	motifs := make(map[string][]string)
+	var pending []string
+	mark := func(rows []string, palette map[string]*paletteSymbol) {
+		for _, row := range rows {
+			for _, chr := range row {
+				if symbol, ok := palette[string(chr)]; ok {
+					symbol.used = true
+				}
+			}
+		}
+	}
+	for _, block := range input.blocks {
+		switch block.mode {
+		case "palette":
+			palette = make(map[string]*paletteSymbol)
+			for idx, entry := range block.entries {
+				if assignment, ok := entry.(*syntax.Assignment); ok {
+					symbol := &paletteSymbol{symbol: assignment.Symbol, position: block.positions[idx]}
+					palette[assignment.Symbol] = symbol
+					all = append(all, symbol)
+				}
+			}
+		case "pattern":
+			pending = block.lines
+		case "action":
+			for _, entry := range block.entries {
+				step, ok := entry.(*syntax.Action)
+				switch {
+				case !ok:
+				case step.Verb == commitAction:
+					mark(pending, palette)
+					pending = nil
+				case step.Verb == stampAction && len(step.Args) == 3:
+					// the motif palette was marked when defined
+					mark(motifs[step.Args[0]], palette)
+				}
+			}
+		case backstitchBlock:
+			for _, entry := range block.entries {
+				if line, ok := entry.(*syntax.Backstitch); ok {
+					mark([]string{line.Symbol}, palette)
+				}
+			}
+		default:
+			if isMotif(block.mode) {
+				name := strings.TrimSpace(strings.TrimPrefix(block.mode, strings.TrimSpace(motifPrefix)))
+				motifs[name] = block.lines
+				mark(block.lines, palette)
+			}
+		}
+	}
+	var issues []LintIssue
+	for _, symbol := range all {
+		if !symbol.used {
+			issues = append(issues, LintIssue{Check: lintUnusedSymbol, Position: symbol.position, Message: fmt.Sprintf("palette symbol never used: %s", symbol.symbol)})
+		}
+	}
+	return issues
+}
+
+func rawColors(input lintInput) []LintIssue {
+	threads := newFlossCatalog()
+	var issues []LintIssue
+	for _, block := range input.blocks {
+		if block.mode != "palette" {
+			continue
+		}
+		for idx, entry := range block.entries {
+			assignment, ok := entry.(*syntax.Assignment)
+			if !ok || assignment.Color == noColor {
+				continue
+			}
+			floss, err := threads.resolve(assignment.Color)
+			if err != nil {
+				continue
+			}
+			components := floss.blend
+			if len(components) == 0 {
+				components = []flossColor{floss}
+			}
+			for _, component := range components {
+				if component.thread.code != "" {
+					continue
+				}
+				message := fmt.Sprintf("color matches no floss: %s", component.input)
+				if _, err := parseColor(component.resolved); err == nil {
+					message = fmt.Sprintf("%s (use %s%s for the nearest floss)", message, nearestPrefix, component.input)
+				}
+				issues = append(issues, LintIssue{Check: lintRawColor, Position: block.positions[idx], Message: message})
+			}
+		}
+	}
+	return issues
+}
+
+func hiddenStitches(input lintInput) []LintIssue {
+	top := make(map[cell]int)
+	for _, e := range input.pattern.entries {
+		if e.mode != isXStitch {
+			continue
+		}
+		for _, at := range e.cells {
+			if layer, ok := top[at]; !ok || e.layer > layer {
+				top[at] = e.layer
+			}
+		}
+	}
+	var issues []LintIssue
+	reported := make(map[Position]bool)
+	for _, e := range input.pattern.entries {
+		if e.mode != isXStitch {
+			continue
+		}
+		for idx, at := range e.cells {
+			layer := top[at]
+			if layer <= e.layer || reported[e.sources[idx]] {
+				continue
+			}
+			reported[e.sources[idx]] = true
+			message := fmt.Sprintf("xstitch hidden by a later layer (%s)", input.actions[layer].start)
+			issues = append(issues, LintIssue{Check: lintHiddenStitch, Position: e.sources[idx], Message: message})
+		}
+	}
+	return issues
+}
+
+func isLine(mode string) bool {
+	switch mode {
+	case isTopLeftBottomRight, isTopRightBottomLeft, isHorizontalLine, isVerticalLine:
+		return true
+	}
+	return false
+}
+
+func stitchLines(input lintInput) []LintIssue {
+	stitched := make(map[cell]bool)
+	for _, e := range input.pattern.entries {
+		if e.mode == isXStitch || isFractional(e.mode) {
+			for _, at := range e.cells {
+				stitched[at] = true
+			}
+		}
+	}
+	var issues []LintIssue
+	reported := make(map[Position]bool)
+	for _, e := range input.pattern.entries {
+		if !isLine(e.mode) {
+			continue
+		}
+		for idx, at := range e.cells {
+			if !stitched[at] || reported[e.sources[idx]] {
+				continue
+			}
+			reported[e.sources[idx]] = true
+			message := fmt.Sprintf("%s and a stitch within the same cell (%dx%d)", e.mode, at.x, at.y)
+			issues = append(issues, LintIssue{Check: lintStitchLine, Position: e.sources[idx], Message: message})
+		}
+	}
+	return issues
+}
+
+func (b *layerBounds) add(at cell) {
+	if !b.set || at.x < b.minX {
+		b.minX = at.x
+	}
+	if !b.set || at.y < b.minY {
+		b.minY = at.y
+	}
+	if !b.set || at.x > b.maxX {
+		b.maxX = at.x
+	}
+	if !b.set || at.y > b.maxY {
+		b.maxY = at.y
+	}
+	b.set = true
+}
+
+// past is true when the bounds reach past the other bounds (on any side).
+func (b layerBounds) past(other layerBounds) bool {
+	return b.minX < other.minX || b.minY < other.minY || b.maxX > other.maxX || b.maxY > other.maxY
+}
+
+func offsetOverflows(input lintInput) []LintIssue {
+	layers := make(map[int]*layerBounds)
+	for _, e := range input.pattern.entries {
+		if _, ok := layers[e.layer]; !ok {
+			layers[e.layer] = &layerBounds{}
+		}
+		for _, at := range e.cells {
+			layers[e.layer].add(at)
+		}
+	}
+	var ordered []int
+	for layer := range layers {
+		ordered = append(ordered, layer)
+	}
+	sort.Ints(ordered)
+	var issues []LintIssue
+	for _, layer := range ordered {
+		bounds := layers[layer]
+		offset := input.actions[layer].offset
+		if offset.x == 0 && offset.y == 0 {
+			continue
+		}
+		others := layerBounds{}
+		for other, otherBounds := range layers {
+			if other != layer {
+				others.add(cell{x: otherBounds.minX, y: otherBounds.minY})
+				others.add(cell{x: otherBounds.maxX, y: otherBounds.maxY})
+			}
+		}
+		if !others.set || !bounds.past(others) {
+			continue
+		}
+		message := fmt.Sprintf("offset %dx%d moves the layer past the other layers", offset.x, offset.y)
+		issues = append(issues, LintIssue{Check: lintOffsetOverflow, Position: input.actions[layer].start, Message: message})
+	}
+	return issues
+}
@@ -0,0 +1,79 @@
+package internal_test
+
+import (
+	"strings"
+	"testing"
+
+	"voidedtech.com/gxs/internal"
+)
+
+func TestLint(t *testing.T) {
+	input := []byte(`palette => {
+    x => red
+    y => #333333
+    u => dmc:310
+    b => blue+dmc:310
+}
+mode => {xstitch}
+pattern => {
+    xxy
+    bxx
+}
+action => {commit}
+mode => {hline}
+pattern => {
+    x
+}
+action => {commit}
+mode => {xstitch}
+pattern => {
+    .x
+}
+palette => {
+    x => dmc:321
+    . => NONE
+}
+action => {commit}
+offset => {3x0}
+mode => {xstitch}
+pattern => {
+    x
+}
+action => {commit}`)
+	issues, err := internal.Lint(input, internal.ParseOptions{}, nil)
+	if err != nil {
+		t.Fatalf("valid pattern: %v", err.Error)
+	}
+	var results []string
+	for _, issue := range issues {
+		results = append(results, issue.String())
+	}
+	expect := []string{
+		"<stdin>:3:5: color matches no floss: #333333 (use nearest:#333333 for the nearest floss) (raw-color)",
+		"<stdin>:4:5: palette symbol never used: u (unused-symbol)",
+		"<stdin>:5:5: color matches no floss: blue (use nearest:blue for the nearest floss) (raw-color)",
+		"<stdin>:9:6: xstitch hidden by a later layer (<stdin>:19:1) (hidden-stitch)",
+		"<stdin>:15:5: hline and a stitch within the same cell (1x1) (stitch-line)",
+		"<stdin>:29:1: offset 3x0 moves the layer past the other layers (offset-overflow)",
+	}
+	if strings.Join(results, "\n") != strings.Join(expect, "\n") {
+		t.Errorf("invalid issues:\n%s", strings.Join(results, "\n"))
+	}
+	issues, err = internal.Lint(input, internal.ParseOptions{}, internal.LintChecks())
+	if err != nil || len(issues) != 0 {
+		t.Error("every check can be disabled")
+	}
+	issues, _ = internal.Lint(input, internal.ParseOptions{}, []string{"raw-color", "offset-overflow"})
+	if len(issues) != 3 {
+		t.Errorf("checks should be disabled: %v", issues)
+	}
+	if _, err := internal.Lint([]byte("palette => {x => red}"), internal.ParseOptions{}, nil); err == nil {
+		t.Error("lint requires a valid pattern")
+	}
+	if err := internal.ValidateLintCheck("unknown"); err == nil || !strings.HasPrefix(err.Error(), "lint: unknown check: unknown") {
+		t.Errorf("invalid check: %v", err)
+	}
+	if err := internal.ValidateLintCheck("stitch-line"); err != nil {
+		t.Error("valid check")
+	}
+}
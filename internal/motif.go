@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+
+	"voidedtech.com/gxs/syntax"
+)
+
+const (
+	motifPrefix   = "motif "
+	stampAction   = "stamp"
+	stampOverride = "palette"
+)
+
+func isStitchMode(mode string) bool {
+	switch mode {
+	case isLeftEdge, isRightEdge, isTopEdge, isBottomEdge, isXStitch, isHorizontalLine, isVerticalLine, isTopLeftBottomRight, isTopRightBottomLeft:
+		return true
+	}
+	return isFractional(mode) || isPoint(mode)
+}
+
+func isMotif(mode string) bool {
+	return mode == strings.TrimSpace(motifPrefix) || strings.HasPrefix(mode, motifPrefix)
+}
+
+// defineMotif captures the pattern of the block with the current mode and palette.
+func defineMotif(block patternBlock, action patternAction, motifs map[string]patternAction) *ParserError {
+	name := strings.TrimSpace(strings.TrimPrefix(block.mode, strings.TrimSpace(motifPrefix)))
+	if name == "" || strings.ContainsAny(name, " \t") {
+		return block.toError("invalid motif name")
+	}
+	if _, ok := motifs[name]; ok {
+		return block.toError(fmt.Sprintf("duplicate motif: %s", name))
+	}
+	if len(action.pattern) > 0 {
+		return block.toError("pattern not committed")
+	}
+	if !isStitchMode(action.stitchMode) {
+		return block.toError("invalid stitch mode")
+	}
+	motifs[name] = patternAction{palette: action.palette, stitchMode: action.stitchMode, pattern: block.lines, start: block.start, positions: block.positions}
+	return nil
+}
+
+// stampMotif commits a motif (stamp name XxY [palette]) at an offset, the
+// current palette overrides the motif palette when requested.
+func stampMotif(block patternBlock, idx int, step *syntax.Action, action patternAction, motifs map[string]patternAction) (patternAction, *ParserError) {
+	parts := append([]string{step.Verb}, step.Args...)
+	if len(parts) < 3 || len(parts) > 4 || (len(parts) == 4 && parts[3] != stampOverride) {
+		return patternAction{}, block.lineToError(idx, "stamp should be: stamp name X[x]Y [palette]")
+	}
+	motif, ok := motifs[parts[1]]
+	if !ok {
+		return patternAction{}, block.lineToError(idx, fmt.Sprintf("undefined motif: %s", parts[1]))
+	}
+	x, y, ok := parsePair(parts[2], 0)
+	if !ok {
+		return patternAction{}, block.lineToError(idx, "stamp offset should be X[x]Y (at least 0)")
+	}
+	stamped := motif
+	stamped.offset = patternOffset{x: x, y: y}
+	stamped.repeat = action.repeat
+	if len(parts) == 4 {
+		palette := make(map[string]flossColor)
+		for symbol, color := range motif.palette {
+			palette[symbol] = color
+		}
+		for symbol, color := range action.palette {
+			palette[symbol] = color
+		}
+		stamped.palette = palette
+	}
+	return stamped, nil
+}
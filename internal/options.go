@@ -1,15 +1,45 @@
 package internal
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 
 	"voidedtech.com/stock"
 )
 
+const (
+	pageLetter         = "letter"
+	pageA4             = "a4"
+	defaultPDFOverlap  = 2
+	defaultPDFCellSize = 10
+	defaultPNGCellSize = 8
+	defaultFabric      = 14
+	defaultStrands     = 2
+	overTwo            = "over2"
+	fabricCount        = "ct"
+)
+
 type (
 	// Option are CLI argument options.
 	Option struct {
 		asciiNoDelimiter bool
+		pdfPageSize      string
+		pdfOverlap       int
+		pdfOverlapSet    bool
+		pdfCellSize      int
+		pngCellSize      int
+		fabric           int
+		fabricOverTwo    bool
+		strands          int
+		margin           float64
+		marginSet        bool
+		htmlInfo         bool
+		symbolChart      bool
+		symbols          string
+		noBoldGridlines  bool
+		noCenterMarkers  bool
+		noAxisTens       bool
 	}
 )
 
@@ -18,6 +48,114 @@ func (o Option) NoDelimiterASCII() bool {
 	return o.asciiNoDelimiter
 }
 
+// PDFPageSize is the paper size used for pdf output.
+func (o Option) PDFPageSize() string {
+	if o.pdfPageSize == "" {
+		return pageLetter
+	}
+	return o.pdfPageSize
+}
+
+// PDFOverlap is the number of rows/columns repeated between pdf pages.
+func (o Option) PDFOverlap() int {
+	if !o.pdfOverlapSet {
+		return defaultPDFOverlap
+	}
+	return o.pdfOverlap
+}
+
+// PDFCellSize is the size of a single stitch cell (in points) for pdf output.
+func (o Option) PDFCellSize() int {
+	if o.pdfCellSize == 0 {
+		return defaultPDFCellSize
+	}
+	return o.pdfCellSize
+}
+
+// PNGCellSize is the number of pixels per cell for png output.
+func (o Option) PNGCellSize() int {
+	if o.pngCellSize == 0 {
+		return defaultPNGCellSize
+	}
+	return o.pngCellSize
+}
+
+// EstimateThread indicates if thread estimates were requested (by setting the fabric).
+func (o Option) EstimateThread() bool {
+	return o.fabric != 0
+}
+
+// Fabric is the fabric description (e.g. 14ct, 28ct over 2).
+func (o Option) Fabric() string {
+	if o.fabric == 0 {
+		return fmt.Sprintf("%dct", defaultFabric)
+	}
+	if o.fabricOverTwo {
+		return fmt.Sprintf("%dct over 2", o.fabric)
+	}
+	return fmt.Sprintf("%dct", o.fabric)
+}
+
+// FabricCount is the number of stitches per inch.
+func (o Option) FabricCount() float64 {
+	if o.fabric == 0 {
+		return defaultFabric
+	}
+	if o.fabricOverTwo {
+		return float64(o.fabric) / 2
+	}
+	return float64(o.fabric)
+}
+
+// Strands is the number of floss strands stitched with.
+func (o Option) Strands() int {
+	if o.strands == 0 {
+		return defaultStrands
+	}
+	return o.strands
+}
+
+// Margin is the fabric margin (in inches) around each side of the design.
+func (o Option) Margin() float64 {
+	if !o.marginSet {
+		return defaultMargin
+	}
+	return o.margin
+}
+
+// InfoHTML indicates if the html output includes the size information header.
+func (o Option) InfoHTML() bool {
+	return o.htmlInfo
+}
+
+// SymbolChart indicates if charts draw a symbol per color (instead of the color itself).
+func (o Option) SymbolChart() bool {
+	return o.symbolChart
+}
+
+// Symbols is the ordered set of symbols assigned to colors.
+func (o Option) Symbols() string {
+	if o.symbols == "" {
+		return asciiSymbols
+	}
+	return o.symbols
+}
+
+// BoldGridlines indicates if every 10th gridline is drawn heavier in charts.
+func (o Option) BoldGridlines() bool {
+	return !o.noBoldGridlines
+}
+
+// CenterMarkers indicates if charts mark the center row/column with arrows on each edge.
+func (o Option) CenterMarkers() bool {
+	return !o.noCenterMarkers
+}
+
+// AxisTens indicates if chart axes are labeled every 10 cells (instead of every cell).
+func (o Option) AxisTens() bool {
+	return !o.noAxisTens
+}
+
 func toBool(s string) (bool, error) {
 	if s == "true" {
 		return true, nil
@@ -28,6 +166,28 @@ func toBool(s string) (bool, error) {
 	return false, NewOptionsError("invalid boolean value")
 }
 
+func toInt(s string, min int) (int, error) {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, NewOptionsError("invalid integer value")
+	}
+	if i < min {
+		return 0, NewOptionsError("integer value too small")
+	}
+	return i, nil
+}
+
+func toFloat(s string) (float64, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, NewOptionsError("invalid decimal value")
+	}
+	if f < 0 {
+		return 0, NewOptionsError("decimal value too small")
+	}
+	return f, nil
+}
+
 // NewOptionsError will create a new options-based error.
 func NewOptionsError(message string) error {
 	return stock.NewBasicCategoryError("options", message)
@@ -46,6 +206,96 @@ func (o *Option) Set(value string) error {
 			return err
 		}
 		o.asciiNoDelimiter = b
+	case "pdf-page-size":
+		switch parts[1] {
+		case pageLetter, pageA4:
+			o.pdfPageSize = parts[1]
+		default:
+			return NewOptionsError("unknown page size")
+		}
+	case "pdf-overlap":
+		i, err := toInt(parts[1], 0)
+		if err != nil {
+			return err
+		}
+		o.pdfOverlap = i
+		o.pdfOverlapSet = true
+	case "pdf-cell-size":
+		i, err := toInt(parts[1], 4)
+		if err != nil {
+			return err
+		}
+		o.pdfCellSize = i
+	case "png-cell-size":
+		i, err := toInt(parts[1], 1)
+		if err != nil {
+			return err
+		}
+		o.pngCellSize = i
+	case "fabric":
+		// e.g. 14, 14ct, 28over2, 28-over-2 or 28ct over 2 (as shown)
+		fabric := strings.NewReplacer(" ", "", "-over-", "over").Replace(strings.ToLower(parts[1]))
+		count := strings.TrimSuffix(fabric, overTwo)
+		i, err := toInt(strings.TrimSuffix(count, fabricCount), 1)
+		if err != nil {
+			return err
+		}
+		o.fabricOverTwo = count != fabric
+		if o.fabricOverTwo && i < 2 {
+			return NewOptionsError("integer value too small")
+		}
+		o.fabric = i
+	case "strands":
+		i, err := toInt(parts[1], 1)
+		if err != nil {
+			return err
+		}
+		if i > skeinStrands {
+			return NewOptionsError("too many strands")
+		}
+		o.strands = i
+	case "margin":
+		f, err := toFloat(parts[1])
+		if err != nil {
+			return err
+		}
+		o.margin = f
+		o.marginSet = true
+	case "html-info":
+		b, err := toBool(parts[1])
+		if err != nil {
+			return err
+		}
+		o.htmlInfo = b
+	case "symbol-chart":
+		b, err := toBool(parts[1])
+		if err != nil {
+			return err
+		}
+		o.symbolChart = b
+	case "symbols":
+		if err := validateSymbols(parts[1]); err != nil {
+			return err
+		}
+		o.symbols = parts[1]
+	case "bold-gridlines":
+		b, err := toBool(parts[1])
+		if err != nil {
+			return err
+		}
+		o.noBoldGridlines = !b
+	case "center-markers":
+		b, err := toBool(parts[1])
+		if err != nil {
+			return err
+		}
+		o.noCenterMarkers = !b
+	case "axis-tens":
+		b, err := toBool(parts[1])
+		if err != nil {
+			return err
+		}
+		o.noAxisTens = !b
 	default:
 		return NewOptionsError("unknown option")
 	}
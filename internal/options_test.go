@@ -33,3 +33,102 @@ func TestSetASCIIDelimiter(t *testing.T) {
 		t.Error("valid")
 	}
 }
+
+func TestSetPDF(t *testing.T) {
+	o := &internal.Option{}
+	if o.PDFPageSize() != "letter" || o.PDFOverlap() != 2 || o.PDFCellSize() != 10 {
+		t.Error("invalid defaults")
+	}
+	if err := o.Set("pdf-page-size=a4"); err != nil || o.PDFPageSize() != "a4" {
+		t.Error("valid")
+	}
+	if err := o.Set("pdf-page-size=tabloid"); err == nil || err.Error() != "options: unknown page size" {
+		t.Error("bad page size")
+	}
+	if err := o.Set("pdf-overlap=0"); err != nil || o.PDFOverlap() != 0 {
+		t.Error("valid")
+	}
+	if err := o.Set("pdf-cell-size=2"); err == nil || err.Error() != "options: integer value too small" {
+		t.Error("bad cell size")
+	}
+	if err := o.Set("pdf-cell-size=abc"); err == nil || err.Error() != "options: invalid integer value" {
+		t.Error("bad cell size")
+	}
+}
+
+func TestSetFabric(t *testing.T) {
+	o := &internal.Option{}
+	if o.EstimateThread() || o.Fabric() != "14ct" || o.FabricCount() != 14 || o.Strands() != 2 {
+		t.Error("invalid defaults")
+	}
+	if err := o.Set("fabric=28over2"); err != nil || !o.EstimateThread() || o.Fabric() != "28ct over 2" || o.FabricCount() != 14 {
+		t.Error("valid")
+	}
+	if err := o.Set("fabric=18"); err != nil || o.Fabric() != "18ct" || o.FabricCount() != 18 {
+		t.Error("valid")
+	}
+	for value, expect := range map[string]string{"14ct": "14ct", "28-over-2": "28ct over 2", "28ct over 2": "28ct over 2", "32CT-over-2": "32ct over 2"} {
+		if err := o.Set("fabric=" + value); err != nil || o.Fabric() != expect {
+			t.Errorf("valid fabric: %s", value)
+		}
+	}
+	if err := o.Set("fabric=1over2"); err == nil || err.Error() != "options: integer value too small" {
+		t.Error("bad fabric")
+	}
+	if err := o.Set("fabric=aida"); err == nil || err.Error() != "options: invalid integer value" {
+		t.Error("bad fabric")
+	}
+	if err := o.Set("strands=3"); err != nil || o.Strands() != 3 {
+		t.Error("valid")
+	}
+	if err := o.Set("strands=7"); err == nil || err.Error() != "options: too many strands" {
+		t.Error("bad strands")
+	}
+}
+
+func TestSetMargin(t *testing.T) {
+	o := &internal.Option{}
+	if o.Margin() != 3 || o.InfoHTML() {
+		t.Error("invalid defaults")
+	}
+	if err := o.Set("margin=0"); err != nil || o.Margin() != 0 {
+		t.Error("valid")
+	}
+	if err := o.Set("margin=2.5"); err != nil || o.Margin() != 2.5 {
+		t.Error("valid")
+	}
+	if err := o.Set("margin=-1"); err == nil || err.Error() != "options: decimal value too small" {
+		t.Error("bad margin")
+	}
+	if err := o.Set("margin=wide"); err == nil || err.Error() != "options: invalid decimal value" {
+		t.Error("bad margin")
+	}
+	if err := o.Set("html-info=true"); err != nil || !o.InfoHTML() {
+		t.Error("valid")
+	}
+}
+
+func TestSetSymbols(t *testing.T) {
+	o := &internal.Option{}
+	if o.SymbolChart() || o.Symbols() != "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789" {
+		t.Error("invalid defaults")
+	}
+	if err := o.Set("symbol-chart=true"); err != nil || !o.SymbolChart() {
+		t.Error("valid")
+	}
+	if err := o.Set("symbols=#@%*"); err != nil || o.Symbols() != "#@%*" {
+		t.Error("valid")
+	}
+	if err := o.Set("symbols=#@#"); err == nil || err.Error() != "options: duplicate symbol: #" {
+		t.Error("bad symbols")
+	}
+	if err := o.Set("symbols=a.b"); err == nil || err.Error() != "options: invalid symbol: '.'" {
+		t.Error("bad symbols")
+	}
+	if err := o.Set("symbols=a b"); err == nil || err.Error() != "options: invalid symbol: ' '" {
+		t.Error("bad symbols")
+	}
+	if err := o.Set("symbols="); err == nil || err.Error() != "options: no symbols given" {
+		t.Error("bad symbols")
+	}
+}
@@ -2,10 +2,8 @@ package internal
 
 import (
 	"fmt"
-	"os"
-	"strconv"
-	"strings"
 
+	"voidedtech.com/gxs/syntax"
 	"voidedtech.com/stock"
 )
 
@@ -13,34 +11,49 @@ type (
 	flossColor struct {
 		input    string
 		resolved string
+		thread   flossThread
+		nearest  bool
+		distance float64
+		blend    []flossColor
 	}
 	patternOffset struct {
 		x int
 		y int
 	}
 	patternBlock struct {
-		lines []string
-		mode  string
-		err   error
+		lines     []string
+		mode      string
+		start     Position
+		positions []Position
+		entries   []syntax.Node
 	}
 	// ParserError is an internal error associated with parsing patterns.
 	ParserError struct {
 		Error     error
 		Backtrace []string
+		// Position is where the error occurred (if known).
+		Position *Position
+		// More are the errors found after this one (in order).
+		More []*ParserError
 	}
 	patternAction struct {
 		palette    map[string]flossColor
 		stitchMode string
 		pattern    []string
 		offset     patternOffset
+		lines      []polyline
+		transforms []string
+		repeat     patternRepeat
+		// the pattern block (and each of its lines) within the source
+		start     Position
+		positions []Position
 	}
 )
 
 const (
-	parserBlockStart = " => {"
-	defaultBlock     = ""
-	paletteAssign    = " => "
-	noColor          = "NONE"
+	noColor        = "NONE"
+	nearestPrefix  = "nearest:"
+	blendSeparator = "+"
 )
 
 // NewParsingError returns a new gxs error for parsing.
@@ -48,198 +61,295 @@ func NewParsingError(message string) error {
 	return stock.NewBasicCategoryError("parsing", message)
 }
 
-func next(stream []string) (patternBlock, int) {
-	idx := 0
-	blockCount := 0
-	block := patternBlock{mode: defaultBlock}
-	for idx < len(stream) {
-		line := strings.TrimSpace(stream[idx])
-		if strings.HasPrefix(line, "#") {
-			line = ""
-		}
-		if len(line) > 0 {
-			if blockCount > 0 {
-				if line == "}" {
-					if len(block.lines) == 0 {
-						return patternBlock{err: NewParsingError("empty block found")}, 0
-					}
-					return block, idx + 1
-				}
-				block.lines = append(block.lines, line)
-			} else {
-				if strings.HasSuffix(line, parserBlockStart) {
-					blockCount++
-					mode, err := getBlockMode(line)
-					if err != nil {
-						return patternBlock{err: err}, 0
-					}
-					block.mode = mode
-				} else {
-					if strings.HasSuffix(line, "}") && strings.Contains(line, parserBlockStart) {
-						sub := line[0 : len(line)-1]
-						parts := strings.Split(sub, parserBlockStart)
-						if len(parts) == 2 {
-							mode, err := getBlockMode(sub)
-							if err != nil {
-								return patternBlock{err: NewParsingError("unable to read single line block")}, 0
-							}
-							block.mode = mode
-							block.lines = []string{parts[1]}
-							return block, 1
-						}
-						return patternBlock{err: NewParsingError("single-line start of block invalid")}, 0
-					}
-					return patternBlock{err: NewParsingError("expected start of block")}, 0
-				}
-			}
-		}
-		idx++
-	}
-	if blockCount > 0 {
-		return patternBlock{err: NewParsingError(fmt.Sprintf("unclosed block at block: %d", blockCount))}, 0
-	}
-	return block, idx
-}
-
-func getBlockMode(line string) (string, error) {
-	modeSection := strings.Split(line, parserBlockStart)
-	if len(modeSection) != 2 {
-		return "", NewParsingError("invalid start block")
-	}
-	return modeSection[0], nil
-}
-
-func (b patternBlock) isMatch(is string) bool {
-	return len(b.lines) == 1 && b.lines[0] == is
-}
-
 func (b patternBlock) toError(message string) *ParserError {
-	return &ParserError{Error: NewParsingError(message), Backtrace: b.lines}
+	start := b.start
+	return &ParserError{Error: NewParsingError(message), Backtrace: b.lines, Position: &start}
 }
 
-func parseBlocks(blocks []patternBlock) ([]patternAction, *ParserError) {
+// parseBlocks converts blocks to actions, recording errors and skipping past
+// them (line by line where possible) until the error limit is reached.
+func parseBlocks(blocks []patternBlock, errs *parserErrors) []patternAction {
 	var actions []patternAction
 	var action patternAction
-	colorLookup := colors()
+	motifs := make(map[string]patternAction)
+	threads := newFlossCatalog()
 	for _, block := range blocks {
+		if errs.full() {
+			return actions
+		}
 		switch block.mode {
 		case "palette":
 			action.palette = make(map[string]flossColor)
-			for _, line := range block.lines {
-				parts := strings.Split(line, paletteAssign)
-				if len(parts) != 2 {
-					return nil, block.toError("invalid palette assignment")
+			for idx, entry := range block.entries {
+				assignment, ok := entry.(*syntax.Assignment)
+				if !ok {
+					errs.add(block.lineToError(idx, "invalid palette assignment"))
+					continue
 				}
-				char := parts[0]
-				color := parts[1]
+				char := assignment.Symbol
+				color := assignment.Color
 				if len(char) != 1 {
-					return nil, block.toError("only single characters allowed")
+					errs.add(block.lineToError(idx, "only single characters allowed"))
+					continue
 				}
-				rawColor := color
-				if val, ok := colorLookup[color]; ok {
-					color = val
+				resolved, err := threads.resolve(color)
+				if err != nil {
+					errs.add(block.lineError(idx, err))
+					continue
 				}
 				if _, ok := action.palette[char]; ok {
-					return nil, block.toError("character re-used within palette")
+					errs.add(block.lineToError(idx, "character re-used within palette"))
+					continue
 				}
-				action.palette[char] = flossColor{input: rawColor, resolved: color}
+				action.palette[char] = resolved
 			}
 		case "pattern":
 			if len(action.pattern) > 0 {
-				return nil, block.toError("pattern not committed")
+				// the new pattern replaces the pending one
+				errs.add(block.toError("pattern not committed"))
 			}
 			action.pattern = block.lines
+			action.start = block.start
+			action.positions = block.positions
 		case "action":
-			if !block.isMatch("commit") {
-				return nil, block.toError("unknown action")
-			}
-			if len(action.pattern) == 0 {
-				return nil, block.toError("no pattern")
-			}
-			switch action.stitchMode {
-			case isLeftEdge, isRightEdge, isTopEdge, isBottomEdge, isXStitch, isHorizontalLine, isVerticalLine, isTopLeftBottomRight, isTopRightBottomLeft:
-				break
-			default:
-				return nil, block.toError("invalid stitch mode")
+			for idx, entry := range block.entries {
+				step, ok := entry.(*syntax.Action)
+				if ok && step.Verb == stampAction {
+					stamped, err := stampMotif(block, idx, step, action, motifs)
+					if err != nil {
+						errs.add(err)
+						continue
+					}
+					actions = append(actions, stamped)
+					action.repeat = patternRepeat{}
+					continue
+				}
+				if !ok || len(step.Args) > 0 {
+					errs.add(block.lineToError(idx, "unknown action"))
+					continue
+				}
+				verb := step.Verb
+				_, isTransform := transforms[verb]
+				if !isTransform && verb != commitAction {
+					errs.add(block.lineToError(idx, "unknown action"))
+					continue
+				}
+				if len(action.pattern) == 0 {
+					errs.add(block.lineToError(idx, "no pattern"))
+					continue
+				}
+				if isTransform {
+					action.transforms = append(action.transforms, verb)
+					continue
+				}
+				if !isStitchMode(action.stitchMode) {
+					// the pending pattern is discarded (as if committed)
+					errs.add(block.lineToError(idx, "invalid stitch mode"))
+				} else {
+					committed := action
+					committed.stitchMode = transformMode(action.transforms, action.stitchMode)
+					actions = append(actions, committed)
+				}
+				action.pattern = []string{}
+				action.stitchMode = ""
+				action.transforms = nil
+				action.repeat = patternRepeat{}
 			}
-			actions = append(actions, action)
-			action.pattern = []string{}
-			action.stitchMode = ""
 		case "offset":
-			if len(block.lines) != 1 {
-				return nil, block.toError("invalid offset")
+			if len(block.entries) != 1 {
+				errs.add(block.toError("invalid offset"))
+				continue
 			}
-			parts := strings.Split(block.lines[0], "x")
-			if len(parts) != 2 {
-				return nil, block.toError("offset should be Width[x]Height")
+			size, ok := block.entries[0].(*syntax.Size)
+			if !ok || size.Bad {
+				errs.add(block.lineToError(0, "offset should be Width[x]Height"))
+				continue
 			}
-			x, err := strconv.Atoi(parts[0])
-			if err != nil {
-				return nil, &ParserError{Error: err, Backtrace: block.lines}
+			action.offset = patternOffset{x: size.Width, y: size.Height}
+		case repeatBlock:
+			if action.repeat.across != 0 {
+				errs.add(block.toError("repeat not committed"))
+				continue
 			}
-			y, err := strconv.Atoi(parts[1])
+			repeat, err := parseRepeat(block)
 			if err != nil {
-				return nil, &ParserError{Error: err, Backtrace: block.lines}
+				errs.add(err)
+				continue
+			}
+			action.repeat = repeat
+		case backstitchBlock:
+			lines, lineErrs := parseBackstitch(block, action.palette, action.offset)
+			for _, err := range lineErrs {
+				errs.add(err)
+			}
+			if len(lines) > 0 {
+				actions = append(actions, patternAction{palette: action.palette, offset: action.offset, lines: lines})
 			}
-			action.offset = patternOffset{x: x, y: y}
 		case "mode":
-			if len(block.lines) != 1 {
-				return nil, block.toError("incorrect stitch mode setting")
+			var mode *syntax.Mode
+			if len(block.entries) == 1 {
+				mode, _ = block.entries[0].(*syntax.Mode)
+			}
+			if mode == nil {
+				errs.add(block.toError("incorrect stitch mode setting"))
+				continue
 			}
-			line := block.lines[0]
 			if action.stitchMode != "" {
-				if action.stitchMode != line {
-					return nil, block.toError("stitching not committed")
+				if action.stitchMode != mode.Stitch {
+					errs.add(block.lineToError(0, "stitching not committed"))
+					continue
 				}
 			}
-			action.stitchMode = line
+			action.stitchMode = mode.Stitch
 		default:
-			return nil, block.toError("unknown mode in block")
+			if !isMotif(block.mode) {
+				errs.add(block.toError("unknown mode in block"))
+				continue
+			}
+			if err := defineMotif(block, action, motifs); err != nil {
+				errs.add(err)
+				continue
+			}
 		}
 	}
 	if len(action.pattern) != 0 {
-		return nil, &ParserError{Error: NewParsingError("uncommitted pattern")}
+		start := action.start
+		errs.add(&ParserError{Error: NewParsingError("uncommitted pattern"), Position: &start})
 	}
-	return actions, nil
+	return actions
 }
 
-func (a patternAction) toPatternError(message string) *ParserError {
-	return &ParserError{Error: NewParsingError(message), Backtrace: a.pattern}
+// cellPosition is where a character (row, column) of the pattern is within the source.
+func (a patternAction) cellPosition(row, column int) Position {
+	if row >= len(a.positions) {
+		return a.start
+	}
+	position := a.positions[row]
+	position.Column += column
+	return position
+}
+
+// toPatternError is an error at a character (row, column) of the pattern.
+func (a patternAction) toPatternError(message string, row, column int) *ParserError {
+	result := &ParserError{Error: NewParsingError(message), Backtrace: a.pattern}
+	if row < len(a.positions) {
+		position := a.cellPosition(row, column)
+		result.Position = &position
+	}
+	return result
 }
 
-func buildPattern(actions []patternAction) (Pattern, *ParserError) {
+// buildPattern lays out the committed actions, every unknown symbol (or bead mode
+// color not from the bead catalog) is recorded (and skipped) until the error limit is reached.
+func buildPattern(actions []patternAction, errs *parserErrors) Pattern {
 	var entries []entry
-	var maxSize = -1
+	var lines []polyline
+	colorLength := make(map[string]float64)
+	var maxWidth = -1
+	var maxHeight = -1
 	colorLegend := make(map[string]int)
-	reverseColors := make(map[string]string)
-	for _, action := range actions {
+	pointLegend := make(map[string]map[string]int)
+	reverseColors := make(map[string]flossColor)
+	beads := beadCatalog()
+	for layer, action := range actions {
+		for _, l := range action.lines {
+			// the last intersection of a row/column does not need another cell,
+			// though a line along the first row/column still needs one
+			for _, point := range l.points {
+				reachX, reachY := point.x-1, point.y-1
+				if reachX < 0 {
+					reachX = 0
+				}
+				if reachY < 0 {
+					reachY = 0
+				}
+				if reachX > maxWidth {
+					maxWidth = reachX
+				}
+				if reachY > maxHeight {
+					maxHeight = reachY
+				}
+			}
+			if _, ok := colorLegend[l.color]; !ok {
+				colorLegend[l.color] = 0
+			}
+			colorLength[l.color] += l.length()
+			reverseColors[l.color] = l.floss
+			lines = append(lines, l)
+		}
 		tracking := make(map[string]map[string][]cell)
-		for rawHeight, line := range action.pattern {
-			height := rawHeight + action.offset.y
-			if height > maxSize {
-				maxSize = height
+		sources := make(map[string]map[string][]Position)
+		// points address the intersection at the top-left of a cell, the last
+		// intersection of a row/column does not need another cell
+		extent := 0
+		if isPoint(action.stitchMode) {
+			extent = 1
+		}
+		patternWidth := 0
+		for _, line := range action.pattern {
+			if len(line) > patternWidth {
+				patternWidth = len(line)
 			}
+		}
+		// overlapping copies of a repeat only stitch a cell once
+		stamped := make(map[string]map[cell]struct{})
+		copies := action.repeat.offsets(transformSize(action.transforms, patternWidth, len(action.pattern)))
+		for rawHeight, line := range action.pattern {
 			for rawWidth, chr := range line {
-				width := rawWidth + action.offset.x
-				if width > maxSize {
-					maxSize = width
-				}
+				cellX, cellY := transformCell(action.transforms, rawWidth, rawHeight, patternWidth, len(action.pattern))
 				symbol := fmt.Sprintf("%c", chr)
-				if color, ok := action.palette[symbol]; ok {
+				color, ok := action.palette[symbol]
+				if !ok {
+					if errs.add(action.toPatternError("symbol unknown", rawHeight, rawWidth)) {
+						return Pattern{}
+					}
+					continue
+				}
+				if _, isBeadColor := beads[color.thread.brand]; action.stitchMode == isBead && color.resolved != noColor && !isBeadColor {
+					if errs.add(action.toPatternError(fmt.Sprintf("bead mode requires a bead: %s", color.input), rawHeight, rawWidth)) {
+						return Pattern{}
+					}
+					continue
+				}
+				for _, copied := range copies {
+					height := cellY + action.offset.y + copied.y
+					width := cellX + action.offset.x + copied.x
+					// points along the first row/column still need a cell
+					reachY, reachX := height-extent, width-extent
+					if reachY < 0 {
+						reachY = 0
+					}
+					if reachX < 0 {
+						reachX = 0
+					}
+					if reachY > maxHeight {
+						maxHeight = reachY
+					}
+					if reachX > maxWidth {
+						maxWidth = reachX
+					}
+					at := cell{x: width + 1, y: height + 1}
+					if _, ok := stamped[color.resolved][at]; ok {
+						continue
+					}
+					if _, ok := stamped[color.resolved]; !ok {
+						stamped[color.resolved] = make(map[cell]struct{})
+					}
+					stamped[color.resolved][at] = struct{}{}
 					if _, hasColor := tracking[color.resolved]; !hasColor {
 						tracking[color.resolved] = make(map[string][]cell)
+						sources[color.resolved] = make(map[string][]Position)
 					}
 					curColor := tracking[color.resolved]
 					if _, hasMode := curColor[action.stitchMode]; !hasMode {
 						curColor[action.stitchMode] = []cell{}
 					}
 					modeSet := curColor[action.stitchMode]
-					modeSet = append(modeSet, cell{x: width + 1, y: height + 1})
+					modeSet = append(modeSet, at)
 					curColor[action.stitchMode] = modeSet
+					sources[color.resolved][action.stitchMode] = append(sources[color.resolved][action.stitchMode], action.cellPosition(rawHeight, rawWidth))
 					tracking[color.resolved] = curColor
-					reverseColors[color.resolved] = color.input
-				} else {
-					return Pattern{}, action.toPatternError("symbol unknown")
+					reverseColors[color.resolved] = color
 				}
 			}
 		}
@@ -249,9 +359,16 @@ func buildPattern(actions []patternAction) (Pattern, *ParserError) {
 			}
 			count := 0
 			for mode, cells := range modes {
-				entry := entry{cells: cells, mode: mode, color: color}
-				count += len(cells)
+				entry := entry{cells: cells, mode: mode, color: color, layer: layer, sources: sources[color][mode]}
 				entries = append(entries, entry)
+				if isPoint(mode) {
+					if _, ok := pointLegend[color]; !ok {
+						pointLegend[color] = make(map[string]int)
+					}
+					pointLegend[color][mode] += len(cells)
+					continue
+				}
+				count += len(cells)
 			}
 			if _, ok := colorLegend[color]; !ok {
 				colorLegend[color] = 0
@@ -259,71 +376,115 @@ func buildPattern(actions []patternAction) (Pattern, *ParserError) {
 			colorLegend[color] += count
 		}
 	}
-	pattern, err := NewPattern(maxSize + 1)
+	pattern, err := NewPattern(maxWidth+1, maxHeight+1)
 	if err != nil {
-		return pattern, &ParserError{Error: err}
+		errs.add(&ParserError{Error: err})
+		return pattern
 	}
 	var colorMapping []colorMap
 	for k, v := range colorLegend {
 		if lookup, ok := reverseColors[k]; ok {
-			mapped := colorMap{input: lookup, output: k, count: v}
+			mapped := colorMap{input: lookup.input, output: k, count: v, thread: lookup.thread, nearest: lookup.nearest, distance: lookup.distance, blend: lookup.blend}
+			mapped.knots = pointLegend[k][isKnot]
+			mapped.beads = pointLegend[k][isBead]
+			mapped.length = colorLength[k]
 			colorMapping = append(colorMapping, mapped)
 			continue
 		}
-		return pattern, &ParserError{Error: NewParsingError("unable to reverse map color")}
+		errs.add(&ParserError{Error: NewParsingError("unable to reverse map color")})
+		return pattern
 	}
 	pattern.colors = colorMapping
 	pattern.entries = entries
-	return pattern, nil
+	pattern.lines = lines
+	return pattern
 }
 
-func parseActions(b []byte) ([]patternAction, *ParserError) {
-	lines := strings.Split(string(b), "\n")
-	var blocks []patternBlock
-	for {
-		block, read := next(lines)
-		if block.err != nil {
-			return nil, &ParserError{Error: block.err, Backtrace: lines}
+// expand is the blocks of a file (the frame) with every include block replaced by
+// the blocks of the included files, it is false when parsing can not continue.
+func expand(b []byte, frame int, frames *[]includeFrame, opts ParseOptions, errs *parserErrors) ([]patternBlock, bool) {
+	source := toSourceFile(b, (*frames)[frame].path)
+	errs.read(source.path)
+	file, err := syntax.Parse(b)
+	if err != nil {
+		// the block structure can not be recovered
+		result := &ParserError{Error: err}
+		if syntaxErr, ok := err.(*syntax.Error); ok {
+			position := source.position(syntaxErr.Pos)
+			result = &ParserError{Error: NewParsingError(syntaxErr.Message), Position: &position}
 		}
-		if read == 0 {
-			break
+		errs.add(result)
+		return nil, false
+	}
+	var blocks []patternBlock
+	for _, node := range file.Blocks() {
+		block := source.toPatternBlock(node)
+		if block.mode != includeBlock {
+			blocks = append(blocks, block)
+			continue
 		}
-		var inserts []string
-		if block.mode != defaultBlock {
-			if block.mode == "include" {
-				for _, line := range block.lines {
-					data, err := os.ReadFile(line)
-					if err != nil {
-						return nil, &ParserError{Error: err, Backtrace: block.lines}
-					}
-					inserts = append(inserts, strings.Split(string(data), "\n")...)
-				}
-			} else {
-				blocks = append(blocks, block)
+		for idx, line := range block.lines {
+			path, data, err := opts.resolve(line, source.path)
+			if err != nil {
+				errs.add(block.lineError(idx, err))
+				continue
+			}
+			if chain, ok := opts.cycle(*frames, frame, path); ok {
+				errs.add(block.lineToError(idx, fmt.Sprintf("include cycle: %s", chain)))
+				continue
+			}
+			*frames = append(*frames, includeFrame{path: path, parent: frame})
+			included, ok := expand(data, len(*frames)-1, frames, opts, errs)
+			blocks = append(blocks, included...)
+			if !ok {
+				return blocks, false
 			}
 		}
-		newLines := inserts
-		newLines = append(newLines, lines[read:]...)
-		lines = newLines
+	}
+	return blocks, true
+}
+
+func parseActions(b []byte, opts ParseOptions, errs *parserErrors) ([]patternBlock, []patternAction) {
+	frames := []includeFrame{{path: opts.File}}
+	blocks, ok := expand(b, 0, &frames, opts, errs)
+	if !ok {
+		return blocks, nil
 	}
 	if len(blocks) == 0 {
-		return nil, &ParserError{Error: NewParsingError("no blocks found")}
+		if len(errs.errors) == 0 {
+			errs.add(&ParserError{Error: NewParsingError("no blocks found")})
+		}
+		return nil, nil
+	}
+	actions := parseBlocks(blocks, errs)
+	if len(actions) == 0 && len(errs.errors) == 0 {
+		errs.add(&ParserError{Error: NewParsingError("no actions, nothing committed?")})
 	}
-	actions, pErr := parseBlocks(blocks)
-	if pErr != nil {
-		return nil, pErr
+	return blocks, actions
+}
+
+// parse is the (include expanded) blocks, the actions and the pattern built from them.
+func parse(b []byte, opts ParseOptions) ([]patternBlock, []patternAction, Pattern, *ParserError) {
+	errs := opts.errors()
+	blocks, actions := parseActions(b, opts, errs)
+	if len(actions) == 0 || errs.full() {
+		return nil, nil, Pattern{}, errs.result()
 	}
-	if len(actions) == 0 {
-		return nil, &ParserError{Error: NewParsingError("no actions, nothing committed?")}
+	pattern := buildPattern(actions, errs)
+	if err := errs.result(); err != nil {
+		return nil, nil, Pattern{}, err
 	}
-	return actions, nil
+	return blocks, actions, pattern, nil
 }
 
-// Parse handles parsing a pattern.
+// Parse handles parsing a pattern (includes are relative to the working directory).
 func Parse(b []byte) (Pattern, *ParserError) {
-	actions, err := parseActions(b)
-	if err != nil {
-		return Pattern{}, err
-	}
-	return buildPattern(actions)
+	return ParseWith(b, ParseOptions{})
+}
+
+// ParseWith handles parsing a pattern with options (e.g. where to find includes), every
+// error found (up to the limit) is returned in source order via the first error.
+func ParseWith(b []byte, opts ParseOptions) (Pattern, *ParserError) {
+	_, _, pattern, err := parse(b, opts)
+	return pattern, err
 }
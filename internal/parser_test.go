@@ -1,7 +1,13 @@
 package internal_test
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"testing/fstest"
 
 	"voidedtech.com/gxs/internal"
 )
@@ -259,7 +265,7 @@ offset => {
 offset => {
 	BADx2
 }`))
-	if err == nil || err.Error.Error() != "strconv.Atoi: parsing \"BAD\": invalid syntax" {
+	if err == nil || err.Error.Error() != "parsing: offset should be Width[x]Height" {
 		t.Error("wrong error")
 		t.Error(err.Error.Error())
 	}
@@ -271,11 +277,56 @@ include => {
 	1
 	2
 }`))
-	if err == nil || err.Error.Error() != "open 1: no such file or directory" {
+	if err == nil || err.Error.Error() != "parsing: include not found: 1" {
 		t.Error("wrong error")
 	}
 }
 
+func TestIncludes(t *testing.T) {
+	files := fstest.MapFS{
+		"main.gxs":           {Data: []byte("include => {parts/palette.gxs}\nmode => {xstitch}\ninclude => {pattern.gxs}\naction => {commit}")},
+		"parts/palette.gxs":  {Data: []byte("include => {colors.gxs}")},
+		"parts/colors.gxs":   {Data: []byte("palette => {x => red}")},
+		"shared/pattern.gxs": {Data: []byte("pattern => {xx}")},
+		"cycle/a.gxs":        {Data: []byte("include => {b.gxs}")},
+		"cycle/b.gxs":        {Data: []byte("# b includes a\ninclude => {../cycle/a.gxs}")},
+		"twice.gxs":          {Data: []byte("include => {\n\tparts/colors.gxs\n\tparts/colors.gxs\n}\nmode => {xstitch}\npattern => {x}\naction => {commit}")},
+	}
+	opts := internal.ParseOptions{File: "main.gxs", FS: files, IncludePaths: []string{"shared"}}
+	p, err := internal.ParseWith(files["main.gxs"].Data, opts)
+	if err != nil {
+		t.Fatalf("includes are relative to the including file: %v", err.Error)
+	}
+	if html, _ := p.ToHTMLPattern(); html.Width != 3 {
+		t.Error("invalid included pattern")
+	}
+	if _, err := internal.ParseWith(files["twice.gxs"].Data, internal.ParseOptions{File: "twice.gxs", FS: files}); err != nil {
+		t.Errorf("including a file twice is not a cycle: %v", err.Error)
+	}
+	opts.IncludePaths = nil
+	_, err = internal.ParseWith(files["main.gxs"].Data, opts)
+	if err == nil || err.Error.Error() != "parsing: include not found: pattern.gxs" {
+		t.Error("include paths are required")
+	}
+	_, err = internal.ParseWith(files["cycle/a.gxs"].Data, internal.ParseOptions{File: "cycle/a.gxs", FS: files})
+	if err == nil || err.Error.Error() != "parsing: include cycle: cycle/a.gxs -> cycle/b.gxs -> cycle/a.gxs" {
+		t.Errorf("wrong error: %v", err)
+	}
+	_, err = internal.ParseWith([]byte("include => {cycle/a.gxs}"), internal.ParseOptions{FS: files})
+	if err == nil || err.Error.Error() != "parsing: include cycle: <stdin> -> cycle/a.gxs -> cycle/b.gxs -> cycle/a.gxs" {
+		t.Errorf("wrong error: %v", err)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "self.gxs"), []byte("include => {self.gxs}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	self := filepath.Join(dir, "self.gxs")
+	_, err = internal.ParseWith([]byte("include => {self.gxs}"), internal.ParseOptions{File: self})
+	if err == nil || err.Error.Error() != fmt.Sprintf("parsing: include cycle: %s -> %s", self, self) {
+		t.Errorf("wrong error: %v", err)
+	}
+}
+
 func TestSingleLineParserError(t *testing.T) {
 	_, err := internal.Parse([]byte(`
 action => {
@@ -293,6 +344,18 @@ action => {action => {commit}
 	}
 }
 
+func TestSingleLineAfterComment(t *testing.T) {
+	_, err := internal.Parse([]byte(`palette => {x => red}
+mode => {xstitch}
+pattern => {x}
+
+# commit the pattern
+action => {commit}`))
+	if err != nil {
+		t.Errorf("single-line blocks are only read once: %v", err.Error)
+	}
+}
+
 func TestSingleLineParser(t *testing.T) {
 	_, err := internal.Parse([]byte(`
 # allow comments
@@ -333,3 +396,541 @@ action => {
 		t.Error("is valid")
 	}
 }
+
+func TestNearestPalette(t *testing.T) {
+	p, err := internal.Parse([]byte(`palette => {
+	x => nearest:rgb(199, 43, 59)
+	y => nearest:#010101
+}
+mode => {
+	xstitch
+}
+pattern => {
+	xy
+}
+action => {
+	commit
+}`))
+	if err != nil {
+		t.Error("is valid")
+	}
+	b, bErr := internal.Build(p, "ascii", &internal.Option{})
+	if bErr != nil {
+		t.Error("is valid")
+	}
+	text := string(b)
+	if !strings.Contains(text, "color: b => nearest:rgb(199, 43, 59) [nearest: red, dmc 321, delta-e: 0.00] (count: 1)") {
+		t.Error("invalid exact match")
+		t.Error(text)
+	}
+	if !strings.Contains(text, "color: a => nearest:#010101 [nearest: black, dmc 310, delta-e: 0.") {
+		t.Error("invalid near match")
+		t.Error(text)
+	}
+	b, bErr = internal.Build(p, "html", &internal.Option{})
+	if bErr != nil || !strings.Contains(string(b), "nearest:#010101 [nearest: black, dmc 310, delta-e: 0.") {
+		t.Error("invalid html legend")
+	}
+	_, err = internal.Parse([]byte(`palette => {
+	x => nearest:notacolor
+}`))
+	if err == nil || err.Error.Error() != "color: unknown color: notacolor" {
+		t.Error("wrong error")
+	}
+}
+
+func TestDMCPalette(t *testing.T) {
+	p, err := internal.Parse([]byte(`palette => {
+	x => dmc:310
+	y => red
+	z => dmc:blanc
+}
+mode => {
+	xstitch
+}
+pattern => {
+	xyz
+}
+action => {
+	commit
+}`))
+	if err != nil {
+		t.Error("is valid")
+	}
+	b, bErr := internal.Build(p, "ascii", &internal.Option{})
+	if bErr != nil {
+		t.Error("is valid")
+	}
+	text := string(b)
+	for _, expect := range []string{"color: a => dmc:310 [black] (count: 1)", "color: b => dmc:blanc [white] (count: 1)", "color: c => red [dmc 321] (count: 1)"} {
+		if !strings.Contains(text, expect) {
+			t.Errorf("missing: %s", expect)
+		}
+	}
+	_, err = internal.Parse([]byte(`palette => {
+	x => dmc:12345
+}`))
+	if err == nil || err.Error.Error() != "parsing: unknown dmc floss" {
+		t.Error("wrong error")
+	}
+}
+
+func TestBackstitch(t *testing.T) {
+	p, err := internal.Parse([]byte(`
+palette => {
+	x => red
+	- => NONE
+}
+offset => {1x0}
+backstitch => {
+	x => 1,1 4,5
+	x => 0,0 2,0 2,2
+}
+`))
+	if err != nil {
+		t.Errorf("valid backstitch: %v", err.Error)
+	}
+	b, bErr := internal.Build(p, internal.InfoMode, &internal.Option{})
+	if bErr != nil || !strings.HasPrefix(string(b), "design: 5x5 stitches") {
+		t.Errorf("invalid size: %s", string(b))
+	}
+	b, bErr = internal.Build(p, internal.ASCIIMode, &internal.Option{})
+	if bErr != nil || !strings.Contains(string(b), "backstitch: red [dmc 321] (length: 9.00)") {
+		t.Errorf("invalid length: %s", string(b))
+	}
+	html, hErr := p.ToHTMLPattern()
+	if hErr != nil || len(html.Lines) != 3 || html.Lines[0].X1 != 32.5 || html.Lines[0].Y2 != 65.5 {
+		t.Errorf("invalid html lines: %v", html.Lines)
+	}
+	for input, expect := range map[string]string{
+		"x => 0,0 3,0": "design: 3x1 stitches",
+		"x => 0,0 0,2": "design: 1x2 stitches",
+	} {
+		p, err := internal.Parse([]byte(fmt.Sprintf("palette => {x => red}\nbackstitch => {%s}\n", input)))
+		if err != nil {
+			t.Errorf("valid backstitch: %v", err.Error)
+			continue
+		}
+		b, bErr := internal.Build(p, internal.InfoMode, &internal.Option{})
+		if bErr != nil || !strings.HasPrefix(string(b), expect) {
+			t.Errorf("%s should be sized %s: %s %v", input, expect, string(b), bErr)
+		}
+	}
+	for input, expect := range map[string]string{
+		"x 1,1 2,2":      "parsing: invalid backstitch line",
+		"y => 1,1 2,2":   "parsing: symbol unknown",
+		"- => 1,1 2,2":   "parsing: backstitch requires a color",
+		"x => 1,1 2":     "parsing: invalid backstitch point",
+		"x => 1,1 -2,2":  "parsing: invalid backstitch point",
+		"x => 1,1":       "parsing: backstitch requires at least 2 points",
+		"x => 1,1 a,b c": "parsing: invalid backstitch point",
+	} {
+		_, err := internal.Parse([]byte(fmt.Sprintf("palette => {\nx => red\n- => NONE\n}\nbackstitch => {%s}\n", input)))
+		if err == nil || err.Error.Error() != expect {
+			t.Errorf("%s should fail with %s", input, expect)
+		}
+	}
+}
+
+func TestBlendPalette(t *testing.T) {
+	p, err := internal.Parse([]byte(`
+palette => {
+	x => dmc:310+dmc:3865
+	y => dmc:310
+}
+mode => {xstitch}
+pattern => {
+	xxy
+}
+action => {commit}
+`))
+	if err != nil {
+		t.Errorf("valid blend: %v", err.Error)
+	}
+	html, hErr := p.ToHTMLPattern()
+	if hErr != nil || html.Cells[5].Style != "background-color:  rgb(125, 124, 121)" {
+		t.Errorf("blend should average: %s", html.Cells[5].Style)
+	}
+	if html.Legend[1] != "color: dmc:310+dmc:3865 [blend: dmc 310 black + dmc 3865 winterwhite] (count 2)" {
+		t.Errorf("invalid legend: %s", html.Legend[1])
+	}
+	opts := &internal.Option{}
+	b, bErr := internal.Build(p, internal.SummaryMode, opts)
+	if bErr != nil {
+		t.Error("valid summary")
+	}
+	var summary internal.ThreadSummary
+	if err := json.Unmarshal(b, &summary); err != nil || len(summary.Colors) != 2 {
+		t.Fatalf("invalid summary: %s", string(b))
+	}
+	if summary.Colors[0].Floss != "dmc 310" || summary.Colors[0].Stitches != 3 || summary.Colors[1].Floss != "dmc 3865" || summary.Colors[1].Stitches != 2 {
+		t.Errorf("each component is counted: %v", summary.Colors)
+	}
+	for input, expect := range map[string]string{
+		"dmc:310+":      "parsing: invalid blend",
+		"dmc:310+NONE":  "parsing: invalid blend",
+		"dmc:310+dmc:1": "parsing: unknown dmc floss",
+		"red+notacolor": "color: unknown color: notacolor",
+	} {
+		_, err := internal.Parse([]byte(fmt.Sprintf("palette => {x => %s}\n", input)))
+		if err == nil || err.Error.Error() != expect {
+			t.Errorf("%s should fail with %s", input, expect)
+		}
+	}
+}
+
+func TestTransforms(t *testing.T) {
+	build := func(mode, pattern, action string) string {
+		p, err := internal.Parse([]byte(fmt.Sprintf(`palette => {
+	x => red
+	y => blue
+	z => NONE
+}
+mode => {%s}
+pattern => {
+%s
+}
+action => {
+%s
+}`, mode, pattern, action)))
+		if err != nil {
+			t.Fatalf("valid pattern: %v", err.Error)
+		}
+		b, bErr := internal.Build(p, internal.HTMLMode, &internal.Option{})
+		if bErr != nil {
+			t.Fatalf("valid html: %v", bErr)
+		}
+		return string(b)
+	}
+	for _, check := range []struct {
+		mode      string
+		pattern   string
+		action    string
+		expect    string
+		expectFor string
+	}{
+		{"tlbrline", "xy\nzz", "mirror-x\ncommit", "trblline", "yx\nzz"},
+		{"leftedge", "xy\nzz", "mirror-x\ncommit", "rightedge", "yx\nzz"},
+		{"topedge", "xy\nzz", "mirror-y\ncommit", "bottomedge", "zz\nxy"},
+		{"quartertl", "xy\nzz", "mirror-y\ncommit", "quarterbl", "zz\nxy"},
+		{"leftedge", "xz\nyz", "rotate90\ncommit", "topedge", "yx\nzz"},
+		{"hline", "xz\nyz", "rotate90\ncommit", "vline", "yx\nzz"},
+		{"threequartertl", "xz\nyz", "rotate90\ncommit", "threequartertr", "yx\nzz"},
+		{"xstitch", "x\nyy", "rotate180\ncommit", "xstitch", "yy\nzx"},
+		{"quartertl", "x\nyy", "rotate180\ncommit", "quarterbr", "yy\nzx"},
+		{"quartertr", "xyz\nzzz", "transpose\ncommit", "quarterbl", "xz\nyz\nzz"},
+		{"halftlbr", "xy\nzz", "mirror-x\nmirror-y\ncommit", "halftlbr", "zz\nyx"},
+		{"knot", "xz\nzz", "rotate180\ncommit", "knot", "zz\nzx"},
+	} {
+		if build(check.mode, check.pattern, check.action) != build(check.expect, check.expectFor, "commit") {
+			t.Errorf("%s (%s) should be %s", check.mode, check.action, check.expect)
+		}
+	}
+	for input, expect := range map[string]string{
+		"mode => {xstitch}\naction => {mirror-x}":                             "parsing: no pattern",
+		"mode => {xstitch}\npattern => {x}\naction => {flip}":                 "parsing: unknown action",
+		"mode => {xstitch}\npattern => {x}\naction => {mirror-x}":             "parsing: uncommitted pattern",
+		"mode => {xstitch}\npattern => {x}\naction => {\ncommit\nrotate90\n}": "parsing: no pattern",
+	} {
+		_, err := internal.Parse([]byte("palette => {x => red}\n" + input))
+		if !hasError(err, expect) {
+			t.Errorf("%s should fail with %s", input, expect)
+		}
+	}
+}
+
+func TestRepeat(t *testing.T) {
+	build := func(pattern, repeat string) internal.HTMLPattern {
+		p, err := internal.Parse([]byte(fmt.Sprintf(`palette => {
+	x => red
+	y => blue
+	z => NONE
+}
+mode => {xstitch}
+%s
+pattern => {
+%s
+}
+action => {commit}`, repeat, pattern)))
+		if err != nil {
+			t.Fatalf("valid pattern: %v", err.Error)
+		}
+		html, hErr := p.ToHTMLPattern()
+		if hErr != nil {
+			t.Fatalf("valid html: %v", hErr)
+		}
+		return html
+	}
+	for _, check := range []struct {
+		pattern string
+		repeat  string
+		expect  string
+	}{
+		{"xy", "repeat => {3x1}", "xyxyxy"},
+		{"xy", "repeat => {3x1 step 3x0}", "xyzxyzxy"},
+		{"xy\nyx", "repeat => {2x2}", "xyxy\nyxyx\nxyxy\nyxyx"},
+		{"xz\nzy", "repeat => {2x2 step 1x2}", "xxz\nzyy\nxxz\nzyy"},
+		{"xyz", "repeat => {1x2 step 0x2}", "xyz\nzzz\nxyz"},
+	} {
+		repeated := build(check.pattern, check.repeat)
+		expect := build(check.expect, "")
+		if fmt.Sprintf("%v", repeated.Cells) != fmt.Sprintf("%v", expect.Cells) {
+			t.Errorf("%s (%s) should be %s", check.pattern, check.repeat, check.expect)
+		}
+	}
+	overlap := build("xx", "repeat => {2x1 step 1x0}")
+	if overlap.Legend[0] != "color: red [dmc 321] (count 3)" {
+		t.Errorf("overlapping copies are stitched once: %v", overlap.Legend)
+	}
+	p, err := internal.Parse([]byte(`palette => {
+	x => red
+	z => NONE
+}
+mode => {leftedge}
+repeat => {2x1}
+pattern => {
+	xz
+}
+action => {
+	mirror-x
+	commit
+}
+mode => {xstitch}
+pattern => {
+	x
+}
+action => {commit}`))
+	if err != nil {
+		t.Errorf("valid pattern: %v", err.Error)
+	}
+	html, _ := p.ToHTMLPattern()
+	if html.Width != 5 || !strings.Contains(string(html.Cells[7].Style), "border-right-style") || !strings.Contains(string(html.Cells[9].Style), "border-right-style") {
+		t.Errorf("repeat applies after transforms and only once: %v", html.Cells)
+	}
+	for input, expect := range map[string]string{
+		"repeat => {0x1}":                   "parsing: repeat should be Across[x]Down (at least 1)",
+		"repeat => {2}":                     "parsing: repeat should be Across[x]Down (at least 1)",
+		"repeat => {2x1 step -1x0}":         "parsing: repeat step should be Width[x]Height (at least 0)",
+		"repeat => {2x1 step 1x1 step 1x1}": "parsing: invalid repeat",
+		"repeat => {2x1}\nrepeat => {3x1}":  "parsing: repeat not committed",
+		"repeat => {\n2x1\n3x1\n}":          "parsing: invalid repeat",
+		"repeat => {2x1}\npattern => {x}":   "parsing: uncommitted pattern",
+	} {
+		_, err := internal.Parse([]byte("palette => {x => red}\nmode => {xstitch}\n" + input))
+		if err == nil || err.Error.Error() != expect {
+			t.Errorf("%s should fail with %s", input, expect)
+		}
+	}
+}
+
+func TestMotifs(t *testing.T) {
+	build := func(input string) internal.HTMLPattern {
+		p, err := internal.Parse([]byte(input))
+		if err != nil {
+			t.Fatalf("valid pattern: %v", err.Error)
+		}
+		html, hErr := p.ToHTMLPattern()
+		if hErr != nil {
+			t.Fatalf("valid html: %v", hErr)
+		}
+		return html
+	}
+	stamped := build(`palette => {
+	x => red
+	z => NONE
+}
+mode => {xstitch}
+motif dot => {
+	zx
+	xz
+}
+palette => {x => blue}
+action => {
+	stamp dot 0x0
+	stamp dot 3x1 palette
+}`)
+	expect := build(`palette => {
+	x => red
+	y => blue
+	z => NONE
+}
+mode => {xstitch}
+pattern => {
+	zxzzz
+	xzzzy
+	zzzyz
+}
+action => {commit}`)
+	if fmt.Sprintf("%v", stamped.Cells) != fmt.Sprintf("%v", expect.Cells) {
+		t.Errorf("invalid stamps: %v", stamped.Cells)
+	}
+	// defining a motif does not commit (or reset) the current mode
+	kept := build(`palette => {x => red}
+mode => {xstitch}
+motif dot => {x}
+pattern => {
+	xx
+}
+action => {
+	commit
+	stamp dot 2x0
+}`)
+	expect = build("palette => {x => red}\nmode => {xstitch}\npattern => {xxx}\naction => {commit}")
+	if fmt.Sprintf("%v", kept.Cells) != fmt.Sprintf("%v", expect.Cells) {
+		t.Errorf("mode should be kept after a motif: %v", kept.Cells)
+	}
+	repeated := build(`palette => {x => red}
+mode => {vline}
+motif bar => {x}
+repeat => {3x1 step 2x0}
+action => {stamp bar 1x0}`)
+	if repeated.Width != 7 || repeated.Legend[0] != "color: red [dmc 321] (count 3)" {
+		t.Errorf("stamps are repeated: %v", repeated.Legend)
+	}
+	for input, expect := range map[string]string{
+		"mode => {xstitch}\nmotif dot => {x}\nmotif dot => {x}":                 "parsing: duplicate motif: dot",
+		"action => {stamp dot 1x1}":                                             "parsing: undefined motif: dot",
+		"mode => {xstitch}\nmotif dot => {x}\naction => {stamp dot}":            "parsing: stamp should be: stamp name X[x]Y [palette]",
+		"mode => {xstitch}\nmotif dot => {x}\naction => {stamp dot 1x1 colors}": "parsing: stamp should be: stamp name X[x]Y [palette]",
+		"mode => {xstitch}\nmotif dot => {x}\naction => {stamp dot -1x1}":       "parsing: stamp offset should be X[x]Y (at least 0)",
+		"motif dot => {x}":                                               "parsing: invalid stitch mode",
+		"mode => {xstitch}\nmotif => {x}":                                "parsing: invalid motif name",
+		"mode => {xstitch}\nmotif a dot => {x}":                          "parsing: invalid motif name",
+		"mode => {xstitch}\npattern => {x}\nmotif dot => {x}":            "parsing: pattern not committed",
+		"mode => {xstitch}\nmotif dot => {y}\naction => {stamp dot 0x0}": "parsing: symbol unknown",
+	} {
+		_, err := internal.Parse([]byte("palette => {x => red}\n" + input))
+		if !hasError(err, expect) {
+			t.Errorf("%s should fail with %s", input, expect)
+		}
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	files := fstest.MapFS{
+		"main.gxs":  {Data: []byte("# colors\ninclude => {inner.gxs}\nmode => {xstitch}\npattern => {\n\txxqx\n}\naction => {commit}")},
+		"inner.gxs": {Data: []byte("palette => {\n  x => red\n}")},
+	}
+	_, err := internal.ParseWith(files["main.gxs"].Data, internal.ParseOptions{File: "main.gxs", FS: files})
+	if err == nil || err.Position == nil {
+		t.Fatal("expected a positioned error")
+	}
+	if err.Diagnostic() != "main.gxs:5:4: parsing: symbol unknown\n\txxqx\n\t  ^" {
+		t.Errorf("invalid diagnostic: %s", err.Diagnostic())
+	}
+	for input, expect := range map[string]string{
+		"palette => {\n  x => red\n  xy => blue\n}":                "<stdin>:3:3: parsing: only single characters allowed\n  xy => blue\n  ^",
+		"palette => {x => red}\naction => {flip}":                  "<stdin>:2:12: parsing: unknown action\naction => {flip}\n           ^",
+		"mode => {xstitch}\n\n  pattern => {\n  x\n":               "<stdin>:3:3: parsing: unclosed block at block: 1\n  pattern => {\n  ^",
+		"palette => {x => red}\nmode => {xstitch}\npattern => {x}": "<stdin>:3:1: parsing: uncommitted pattern\npattern => {x}\n^",
+		"include => {\n missing.gxs\n}":                            "<stdin>:2:2: parsing: include not found: missing.gxs\n missing.gxs\n ^",
+	} {
+		_, err := internal.ParseWith([]byte(input), internal.ParseOptions{FS: files})
+		if err == nil || err.Diagnostic() != expect {
+			t.Errorf("invalid diagnostic for %s: %v", input, err)
+		}
+	}
+	_, err = internal.Parse([]byte(""))
+	if err == nil || err.Position != nil || err.Diagnostic() != "parsing: no blocks found" {
+		t.Error("no position for an empty pattern")
+	}
+}
+
+// hasError is true when any of the errors found is the expected error.
+func hasError(err *internal.ParserError, expect string) bool {
+	if err == nil {
+		return false
+	}
+	for _, e := range err.Errors() {
+		if e.Error.Error() == expect {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAllErrors(t *testing.T) {
+	input := []byte(`palette => {
+x => red
+yy => blue
+}
+mode => {xstitch}
+pattern => {
+xqx
+qxz
+}
+action => {commit}
+mode => {bogus}
+pattern => {
+x
+}
+action => {commit}
+pattern => {
+x
+}`)
+	_, err := internal.Parse(input)
+	if err == nil {
+		t.Fatal("expected errors")
+	}
+	var results []string
+	for _, e := range err.Errors() {
+		results = append(results, fmt.Sprintf("%s: %s", e.Position, e.Error.Error()))
+	}
+	expect := []string{
+		"<stdin>:3:1: parsing: only single characters allowed",
+		"<stdin>:7:2: parsing: symbol unknown",
+		"<stdin>:8:1: parsing: symbol unknown",
+		"<stdin>:8:3: parsing: symbol unknown",
+		"<stdin>:15:12: parsing: invalid stitch mode",
+		"<stdin>:16:1: parsing: uncommitted pattern",
+	}
+	if strings.Join(results, "\n") != strings.Join(expect, "\n") {
+		t.Errorf("invalid errors: %v", results)
+	}
+	if len(err.Diagnostics()) != len(expect) {
+		t.Error("invalid diagnostics")
+	}
+	_, err = internal.ParseWith(input, internal.ParseOptions{MaxErrors: 2})
+	if err == nil || len(err.Errors()) != 3 {
+		t.Fatal("expected limited errors")
+	}
+	if last := err.Errors()[2]; last.Position != nil || last.Error.Error() != "parsing: too many errors" {
+		t.Errorf("invalid limit: %v", last.Error)
+	}
+	if err.Found() != 2 {
+		t.Error("the limit marker is not an error found")
+	}
+	_, err = internal.ParseWith([]byte("palette => {\nx => red\n}\nmode => {xstitch}\npattern => {\nqxq\n}\naction => {commit}"), internal.ParseOptions{MaxErrors: 2})
+	if err == nil || len(err.Errors()) != 2 || err.Found() != 2 {
+		t.Errorf("errors at the limit are not too many: %v", err.Diagnostics())
+	}
+	_, err = internal.Parse([]byte("palette => {x => red}\nmode => {xstitch}\npattern => {x}\naction => {commit}"))
+	if err != nil {
+		t.Errorf("valid pattern: %v", err.Error)
+	}
+}
+
+func TestCommentsAndSymbols(t *testing.T) {
+	p, err := internal.Parse([]byte(`palette => { # the colors
+    = => red # '=' and '>' are symbols
+    > => #333333
+}
+mode => {xstitch} # full stitches
+pattern => {
+    # rows may contain '=>'
+    =>
+    >=   # the second row
+}
+action => {commit}`))
+	if err != nil {
+		t.Fatalf("comments and symbols: %v", err.Error)
+	}
+	html, hErr := p.ToHTMLPattern()
+	if hErr != nil || html.Width != 3 || html.Height != 3 || len(html.Legend) != 2 {
+		t.Errorf("invalid pattern: %v", html.Legend)
+	}
+	_, err = internal.Parse([]byte("palette => {\n  x => y => z\n}"))
+	if err == nil || err.Error.Error() != "parsing: invalid palette assignment" || err.Position.Line != 2 {
+		t.Error("ambiguous palette assignment")
+	}
+}
@@ -0,0 +1,420 @@
+package internal
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	pdfMargin      = 36.0
+	pdfHeader      = 24.0
+	pdfFooter      = 24.0
+	pdfGutter      = 16.0
+	pdfLegendLine  = 14.0
+	pdfCatalogID   = 1
+	pdfPagesID     = 2
+	pdfFontID      = 3
+	pdfStitchWidth = 0.18
+	pdfLineWidth   = 0.12
+	pdfPointSize   = 0.4
+	pdfBoldWidth   = 0.75
+)
+
+type (
+	pdfDocument struct {
+		objects [][]byte
+		pages   []int
+		width   float64
+		height  float64
+	}
+	pdfCanvas struct {
+		b      bytes.Buffer
+		height float64
+	}
+	pdfTile struct {
+		page     int
+		startX   int
+		endX     int
+		startY   int
+		endY     int
+		hasLeft  bool
+		hasRight bool
+		hasAbove bool
+		hasBelow bool
+	}
+)
+
+var (
+	pdfBlack = color.RGBA{A: 255}
+	pdfWhite = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	pdfGrid  = color.RGBA{R: 192, G: 192, B: 192, A: 255}
+	pdfShade = color.RGBA{R: 235, G: 235, B: 235, A: 255}
+)
+
+func pdfPageDimensions(size string) (float64, float64) {
+	if size == pageA4 {
+		return 595, 842
+	}
+	return 612, 792
+}
+
+func newPDFDocument(width, height float64) *pdfDocument {
+	doc := &pdfDocument{width: width, height: height}
+	doc.add(fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pdfPagesID))
+	doc.add("")
+	doc.add("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>")
+	return doc
+}
+
+func (d *pdfDocument) add(body string) int {
+	d.objects = append(d.objects, []byte(body))
+	return len(d.objects)
+}
+
+func (d *pdfDocument) addPage(c *pdfCanvas) error {
+	var compressed bytes.Buffer
+	w := zlib.NewWriter(&compressed)
+	if _, err := w.Write(c.b.Bytes()); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	stream := fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", compressed.Len(), compressed.String())
+	content := d.add(stream)
+	page := d.add(fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 %d 0 R >> >> /Contents %d 0 R >>", pdfPagesID, pdfNumber(d.width), pdfNumber(d.height), pdfFontID, content))
+	d.pages = append(d.pages, page)
+	return nil
+}
+
+func (d *pdfDocument) bytes() []byte {
+	var kids []string
+	for _, page := range d.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", page))
+	}
+	d.objects[pdfPagesID-1] = []byte(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	var offsets []int
+	for idx, obj := range d.objects {
+		offsets = append(offsets, b.Len())
+		b.WriteString(fmt.Sprintf("%d 0 obj\n", idx+1))
+		b.Write(obj)
+		b.WriteString("\nendobj\n")
+	}
+	xref := b.Len()
+	b.WriteString(fmt.Sprintf("xref\n0 %d\n", len(d.objects)+1))
+	b.WriteString("0000000000 65535 f \n")
+	for _, offset := range offsets {
+		b.WriteString(fmt.Sprintf("%010d 00000 n \n", offset))
+	}
+	b.WriteString(fmt.Sprintf("trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(d.objects)+1, pdfCatalogID, xref))
+	return b.Bytes()
+}
+
+func pdfNumber(f float64) string {
+	return strconv.FormatFloat(math.Round(f*1000)/1000, 'f', -1, 64)
+}
+
+func pdfColor(c color.RGBA) string {
+	return fmt.Sprintf("%s %s %s", pdfNumber(float64(c.R)/255), pdfNumber(float64(c.G)/255), pdfNumber(float64(c.B)/255))
+}
+
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r < 32 || r > 126:
+			b.WriteRune('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func (c *pdfCanvas) y(y float64) string {
+	return pdfNumber(c.height - y)
+}
+
+func (c *pdfCanvas) line(x1, y1, x2, y2 float64, col color.RGBA, width float64) {
+	c.b.WriteString(fmt.Sprintf("%s RG %s w %s %s m %s %s l S\n", pdfColor(col), pdfNumber(width), pdfNumber(x1), c.y(y1), pdfNumber(x2), c.y(y2)))
+}
+
+func (c *pdfCanvas) point(x, y float64, col color.RGBA, size float64) {
+	// a zero length line with round caps is a dot
+	c.b.WriteString(fmt.Sprintf("%s RG 1 J %s w %s %s m %s %s l S 0 J\n", pdfColor(col), pdfNumber(size), pdfNumber(x), c.y(y), pdfNumber(x), c.y(y)))
+}
+
+func (c *pdfCanvas) polygon(points [][]float64, fill color.RGBA) {
+	c.b.WriteString(fmt.Sprintf("%s rg", pdfColor(fill)))
+	for idx, point := range points {
+		op := "l"
+		if idx == 0 {
+			op = "m"
+		}
+		c.b.WriteString(fmt.Sprintf(" %s %s %s", pdfNumber(point[0]), c.y(point[1]), op))
+	}
+	c.b.WriteString(" f\n")
+}
+
+func (c *pdfCanvas) clip(x, y, w, h float64) {
+	c.b.WriteString(fmt.Sprintf("q %s %s %s %s re W n\n", pdfNumber(x), c.y(y+h), pdfNumber(w), pdfNumber(h)))
+}
+
+func (c *pdfCanvas) unclip() {
+	c.b.WriteString("Q\n")
+}
+
+func (c *pdfCanvas) rect(x, y, w, h float64, fill color.RGBA, stroke bool) {
+	op := "f"
+	if stroke {
+		op = "B"
+	}
+	c.b.WriteString(fmt.Sprintf("%s rg 0 0 0 RG 0.25 w %s %s %s %s re %s\n", pdfColor(fill), pdfNumber(x), c.y(y+h), pdfNumber(w), pdfNumber(h), op))
+}
+
+func (c *pdfCanvas) outline(x, y, w, h float64, col color.RGBA, width float64) {
+	c.b.WriteString(fmt.Sprintf("%s RG %s w %s %s %s %s re S\n", pdfColor(col), pdfNumber(width), pdfNumber(x), c.y(y+h), pdfNumber(w), pdfNumber(h)))
+}
+
+func (c *pdfCanvas) text(x, y, size float64, value string) {
+	c.b.WriteString(fmt.Sprintf("0 0 0 rg BT /F1 %s Tf %s %s Td (%s) Tj ET\n", pdfNumber(size), pdfNumber(x), c.y(y), pdfEscape(value)))
+}
+
+func (c *pdfCanvas) centered(x, y, size float64, value string) {
+	// Helvetica digits/letters average roughly half the font size in width
+	c.text(x-float64(len(value))*size*0.25, y, size, value)
+}
+
+func pdfRanges(size, perPage, overlap int) [][]int {
+	var ranges [][]int
+	start := 1
+	for {
+		end := start + perPage - 1
+		if end > size {
+			end = size
+		}
+		ranges = append(ranges, []int{start, end})
+		if end == size {
+			break
+		}
+		start = end + 1 - overlap
+	}
+	return ranges
+}
+
+func pdf(p Pattern, opts *Option) ([]byte, error) {
+	width, height := pdfPageDimensions(opts.PDFPageSize())
+	cellSize := float64(opts.PDFCellSize())
+	overlap := opts.PDFOverlap()
+	perCols := int((width - 2*pdfMargin - pdfGutter) / cellSize)
+	perRows := int((height - 2*pdfMargin - pdfGutter - pdfHeader - pdfFooter) / cellSize)
+	if perCols <= overlap || perRows <= overlap {
+		return nil, NewTemplateError("pdf cell size and overlap leave no room on a page")
+	}
+	resolved, err := p.resolveColors()
+	if err != nil {
+		return nil, err
+	}
+	symbols := p.chartSymbols(opts)
+	legend := p.legend(symbols)
+	legendTop := pdfMargin + pdfHeader + (height-2*pdfMargin)*0.4 + pdfLegendLine
+	coverLines := int((height - pdfMargin - pdfFooter - legendTop) / pdfLegendLine)
+	perLegendPage := int((height - 2*pdfMargin - pdfHeader - pdfFooter) / pdfLegendLine)
+	coverPages := 1
+	if len(legend) > coverLines {
+		remain := len(legend) - coverLines
+		coverPages += (remain + perLegendPage - 1) / perLegendPage
+	}
+	var tiles []pdfTile
+	colRanges := pdfRanges(p.width, perCols, overlap)
+	rowRanges := pdfRanges(p.height, perRows, overlap)
+	for rowIdx, rows := range rowRanges {
+		for colIdx, cols := range colRanges {
+			tiles = append(tiles, pdfTile{
+				page:     coverPages + len(tiles) + 1,
+				startX:   cols[0],
+				endX:     cols[1],
+				startY:   rows[0],
+				endY:     rows[1],
+				hasLeft:  colIdx > 0,
+				hasRight: colIdx < len(colRanges)-1,
+				hasAbove: rowIdx > 0,
+				hasBelow: rowIdx < len(rowRanges)-1,
+			})
+		}
+	}
+	total := coverPages + len(tiles)
+	doc := newPDFDocument(width, height)
+
+	cover := &pdfCanvas{height: height}
+	cover.text(pdfMargin, pdfMargin+12, 14, "gxs pattern")
+	cover.text(pdfMargin, pdfMargin+pdfHeader, 9, fmt.Sprintf("size: %d x %d stitches, %d chart page(s), overlap: %d", p.width, p.height, len(tiles), overlap))
+	mapTop := pdfMargin + pdfHeader + 12
+	mapSize := (height-2*pdfMargin)*0.4 - 24
+	if mapWidth := width - 2*pdfMargin; mapWidth < mapSize {
+		mapSize = mapWidth
+	}
+	longest := p.width
+	if p.height > longest {
+		longest = p.height
+	}
+	scale := mapSize / float64(longest)
+	cover.outline(pdfMargin, mapTop, float64(p.width)*scale, float64(p.height)*scale, pdfBlack, 1)
+	for _, tile := range tiles {
+		x := pdfMargin + float64(tile.startX-1)*scale
+		y := mapTop + float64(tile.startY-1)*scale
+		w := float64(tile.endX-tile.startX+1) * scale
+		h := float64(tile.endY-tile.startY+1) * scale
+		cover.outline(x, y, w, h, pdfBlack, 0.5)
+		cover.centered(x+w/2, y+h/2+4, 10, strconv.Itoa(tile.page))
+	}
+	page := cover
+	line := 0
+	top := legendTop
+	limit := coverLines
+	page.text(pdfMargin, top-4, 10, "legend")
+	for _, entry := range legend {
+		if line >= limit {
+			page.centered(width/2, height-pdfMargin, 8, fmt.Sprintf("page %d of %d", len(doc.pages)+1, total))
+			if err := doc.addPage(page); err != nil {
+				return nil, err
+			}
+			page = &pdfCanvas{height: height}
+			page.text(pdfMargin, pdfMargin+12, 10, "legend (continued)")
+			top = pdfMargin + pdfHeader
+			line = 0
+			limit = perLegendPage
+		}
+		y := top + float64(line)*pdfLegendLine
+		if entry.symbol != "" {
+			page.rect(pdfMargin, y, 10, 10, pdfWhite, true)
+			page.centered(pdfMargin+5, y+8, 8, entry.symbol)
+		} else if swatch, err := parseColor(entry.color.output); err == nil {
+			page.rect(pdfMargin, y, 10, 10, swatch, true)
+		}
+		page.text(pdfMargin+16, y+8, 9, entry.text)
+		line++
+	}
+	page.centered(width/2, height-pdfMargin, 8, fmt.Sprintf("page %d of %d", len(doc.pages)+1, total))
+	if err := doc.addPage(page); err != nil {
+		return nil, err
+	}
+
+	index := p.cellIndex()
+	for _, tile := range tiles {
+		c := &pdfCanvas{height: height}
+		c.b.WriteString("1 J\n")
+		c.text(pdfMargin, pdfMargin+12, 10, fmt.Sprintf("page %d of %d: columns %d-%d, rows %d-%d", tile.page, total, tile.startX, tile.endX, tile.startY, tile.endY))
+		originX := pdfMargin + pdfGutter
+		originY := pdfMargin + pdfHeader + pdfGutter
+		cols := tile.endX - tile.startX + 1
+		rows := tile.endY - tile.startY + 1
+		for y := tile.startY; y <= tile.endY; y++ {
+			for x := tile.startX; x <= tile.endX; x++ {
+				shared := (tile.hasLeft && x < tile.startX+overlap) ||
+					(tile.hasRight && x > tile.endX-overlap) ||
+					(tile.hasAbove && y < tile.startY+overlap) ||
+					(tile.hasBelow && y > tile.endY-overlap)
+				if shared {
+					c.rect(originX+float64(x-tile.startX)*cellSize, originY+float64(y-tile.startY)*cellSize, cellSize, cellSize, pdfShade, false)
+				}
+			}
+		}
+		for idx := 0; idx <= cols; idx++ {
+			x := originX + float64(idx)*cellSize
+			c.line(x, originY, x, originY+float64(rows)*cellSize, pdfGrid, 0.25)
+		}
+		for idx := 0; idx <= rows; idx++ {
+			y := originY + float64(idx)*cellSize
+			c.line(originX, y, originX+float64(cols)*cellSize, y, pdfGrid, 0.25)
+		}
+		for _, x := range boldLines(p.width, opts) {
+			if x >= tile.startX-1 && x <= tile.endX {
+				pos := originX + float64(x+1-tile.startX)*cellSize
+				c.line(pos, originY, pos, originY+float64(rows)*cellSize, pdfBlack, pdfBoldWidth)
+			}
+		}
+		for _, y := range boldLines(p.height, opts) {
+			if y >= tile.startY-1 && y <= tile.endY {
+				pos := originY + float64(y+1-tile.startY)*cellSize
+				c.line(originX, pos, originX+float64(cols)*cellSize, pos, pdfBlack, pdfBoldWidth)
+			}
+		}
+		centerX, centerY := p.center()
+		for _, arrow := range centerArrows(centerX-float64(tile.startX-1), centerY-float64(tile.startY-1), float64(cols), float64(rows), opts) {
+			c.polygon(arrow.polygon(cellSize, originX, originY), pdfBlack)
+		}
+		labelSize := cellSize * 0.45
+		for x := tile.startX; x <= tile.endX; x++ {
+			if !isAxisLabel(x, opts) {
+				continue
+			}
+			c.centered(originX+(float64(x-tile.startX)+0.5)*cellSize, originY-3, labelSize, strconv.Itoa(x))
+		}
+		for y := tile.startY; y <= tile.endY; y++ {
+			if !isAxisLabel(y, opts) {
+				continue
+			}
+			c.text(pdfMargin, originY+(float64(y-tile.startY)+0.7)*cellSize, labelSize, strconv.Itoa(y))
+		}
+		for y := tile.startY; y <= tile.endY; y++ {
+			for x := tile.startX; x <= tile.endX; x++ {
+				left := originX + float64(x-tile.startX)*cellSize
+				top := originY + float64(y-tile.startY)*cellSize
+				for _, e := range index[cell{x: x, y: y}] {
+					if symbol, ok := symbols[e.color]; ok && e.mode == isXStitch {
+						c.centered(left+cellSize/2, top+cellSize*0.8, cellSize*0.7, symbol)
+						continue
+					}
+					lineWidth := pdfStitchWidth
+					if isBackstitch(e.mode) {
+						lineWidth = pdfLineWidth
+					}
+					for _, s := range stitchSegments(e.mode) {
+						c.line(left+s.x1*cellSize, top+s.y1*cellSize, left+s.x2*cellSize, top+s.y2*cellSize, resolved[e.color], lineWidth*cellSize)
+					}
+				}
+			}
+		}
+		c.clip(originX, originY, float64(cols)*cellSize, float64(rows)*cellSize)
+		for _, l := range p.lines {
+			l.segments(func(from, to gridPoint) {
+				// intersection x is the left of cell x+1
+				c.line(originX+float64(from.x+1-tile.startX)*cellSize, originY+float64(from.y+1-tile.startY)*cellSize, originX+float64(to.x+1-tile.startX)*cellSize, originY+float64(to.y+1-tile.startY)*cellSize, resolved[l.color], pdfLineWidth*cellSize)
+			})
+		}
+		c.unclip()
+		for _, e := range p.entries {
+			if !isPoint(e.mode) {
+				continue
+			}
+			for _, point := range e.cells {
+				// intersections on the right/bottom edge of a tile belong to the next cells over
+				if point.x < tile.startX || point.x > tile.endX+1 || point.y < tile.startY || point.y > tile.endY+1 {
+					continue
+				}
+				x := originX + float64(point.x-tile.startX)*cellSize
+				y := originY + float64(point.y-tile.startY)*cellSize
+				c.point(x, y, resolved[e.color], pdfPointSize*cellSize)
+				if e.mode == isBead {
+					c.point(x, y, pdfWhite, pdfPointSize*cellSize/2)
+				}
+			}
+		}
+		c.centered(width/2, height-pdfMargin, 8, fmt.Sprintf("page %d of %d", tile.page, total))
+		if err := doc.addPage(c); err != nil {
+			return nil, err
+		}
+	}
+	return doc.bytes(), nil
+}
@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+)
+
+func fillSquare(img *image.RGBA, x, y, size int, c color.RGBA) {
+	draw.Draw(img, image.Rect(x, y, x+size, y+size), &image.Uniform{C: c}, image.Point{}, draw.Src)
+}
+
+func drawRing(img *image.RGBA, cx, cy, inner, outer float64, c color.RGBA) {
+	for y := int(cy - outer); y <= int(cy+outer); y++ {
+		for x := int(cx - outer); x <= int(cx+outer); x++ {
+			distance := math.Hypot(float64(x)+0.5-cx, float64(y)+0.5-cy)
+			if distance <= outer && distance >= inner && image.Pt(x, y).In(img.Bounds()) {
+				img.SetRGBA(x, y, c)
+			}
+		}
+	}
+}
+
+func drawLine(img *image.RGBA, x1, y1, x2, y2 float64, thickness int, c color.RGBA) {
+	steps := int(math.Max(math.Abs(x2-x1), math.Abs(y2-y1))) + 1
+	half := float64(thickness) / 2
+	for step := 0; step <= steps; step++ {
+		ratio := float64(step) / float64(steps)
+		x := x1 + (x2-x1)*ratio - half
+		y := y1 + (y2-y1)*ratio - half
+		fillSquare(img, int(math.Round(x)), int(math.Round(y)), thickness, c)
+	}
+}
+
+func raster(p Pattern, opts *Option) ([]byte, error) {
+	resolved, err := p.resolveColors()
+	if err != nil {
+		return nil, err
+	}
+	cellSize := opts.PNGCellSize()
+	thickness := cellSize / 5
+	if thickness < 1 {
+		thickness = 1
+	}
+	// fractional stitches are drawn as thick strokes of the stitch path
+	fractionThickness := cellSize / 3
+	if fractionThickness < 1 {
+		fractionThickness = 1
+	}
+	img := image.NewRGBA(image.Rect(0, 0, p.width*cellSize, p.height*cellSize))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	index := p.cellIndex()
+	for _, backstitch := range []bool{false, true} {
+		for y := 1; y <= p.height; y++ {
+			for x := 1; x <= p.width; x++ {
+				left := float64((x - 1) * cellSize)
+				top := float64((y - 1) * cellSize)
+				for _, e := range index[cell{x: x, y: y}] {
+					if isBackstitch(e.mode) != backstitch {
+						continue
+					}
+					c := resolved[e.color]
+					if e.mode == isXStitch {
+						fillSquare(img, int(left), int(top), cellSize, c)
+						continue
+					}
+					width := thickness
+					if !backstitch {
+						width = fractionThickness
+					}
+					for _, s := range stitchSegments(e.mode) {
+						drawLine(img, left+s.x1*float64(cellSize), top+s.y1*float64(cellSize), left+s.x2*float64(cellSize), top+s.y2*float64(cellSize), width, c)
+					}
+				}
+			}
+		}
+	}
+	for _, l := range p.lines {
+		l.segments(func(from, to gridPoint) {
+			drawLine(img, float64(from.x*cellSize), float64(from.y*cellSize), float64(to.x*cellSize), float64(to.y*cellSize), thickness, resolved[l.color])
+		})
+	}
+	radius := float64(cellSize) / 4
+	for _, e := range p.entries {
+		if !isPoint(e.mode) {
+			continue
+		}
+		inner := 0.0
+		if e.mode == isBead {
+			inner = radius / 2
+		}
+		for _, c := range e.cells {
+			drawRing(img, float64((c.x-1)*cellSize), float64((c.y-1)*cellSize), inner, radius, resolved[e.color])
+		}
+	}
+	var b bytes.Buffer
+	if err := png.Encode(&b, img); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
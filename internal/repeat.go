@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"strconv"
+	"strings"
+
+	"voidedtech.com/gxs/syntax"
+)
+
+const (
+	repeatBlock = "repeat"
+	repeatSize  = "x"
+)
+
+type (
+	// patternRepeat stamps a pattern across (columns) and down (rows), every
+	// copy moved by the step (the pattern size when no step is given).
+	patternRepeat struct {
+		across  int
+		down    int
+		stepX   int
+		stepY   int
+		stepSet bool
+	}
+)
+
+func parsePair(value string, min int) (int, int, bool) {
+	parts := strings.Split(value, repeatSize)
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	x, err := strconv.Atoi(parts[0])
+	if err != nil || x < min {
+		return 0, 0, false
+	}
+	y, err := strconv.Atoi(parts[1])
+	if err != nil || y < min {
+		return 0, 0, false
+	}
+	return x, y, true
+}
+
+func parseRepeat(block patternBlock) (patternRepeat, *ParserError) {
+	if len(block.entries) != 1 {
+		return patternRepeat{}, block.toError("invalid repeat")
+	}
+	repeat, ok := block.entries[0].(*syntax.Repeat)
+	if !ok {
+		return patternRepeat{}, block.lineToError(0, "invalid repeat")
+	}
+	if repeat.Count.Bad || repeat.Count.Width < 1 || repeat.Count.Height < 1 {
+		return patternRepeat{}, block.lineToError(0, "repeat should be Across[x]Down (at least 1)")
+	}
+	result := patternRepeat{across: repeat.Count.Width, down: repeat.Count.Height}
+	if step := repeat.Step; step != nil {
+		if step.Bad || step.Width < 0 || step.Height < 0 {
+			return patternRepeat{}, block.lineToError(0, "repeat step should be Width[x]Height (at least 0)")
+		}
+		result.stepX = step.Width
+		result.stepY = step.Height
+		result.stepSet = true
+	}
+	return result, nil
+}
+
+// offsets are the offsets of every copy of a width by height pattern.
+func (r patternRepeat) offsets(width, height int) []patternOffset {
+	if r.across == 0 {
+		return []patternOffset{{}}
+	}
+	stepX, stepY := width, height
+	if r.stepSet {
+		stepX, stepY = r.stepX, r.stepY
+	}
+	var results []patternOffset
+	for down := 0; down < r.down; down++ {
+		for across := 0; across < r.across; across++ {
+			results = append(results, patternOffset{x: across * stepX, y: down * stepY})
+		}
+	}
+	return results
+}
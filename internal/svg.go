@@ -0,0 +1,158 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+const (
+	svgCell        = 10
+	svgStitchWidth = 1.8
+	svgLineWidth   = 1.2
+	svgPointSize   = 2.5
+	svgLegendLine  = 12
+	svgGridColor   = "#c0c0c0"
+	svgBoldWidth   = 1.0
+)
+
+func svgFloat(f float64) string {
+	return fmt.Sprintf("%g", float64(int(f*100+0.5))/100)
+}
+
+func svgLine(b *bytes.Buffer, x1, y1, x2, y2 float64, color string, width float64) {
+	b.WriteString(fmt.Sprintf("<line x1=\"%s\" y1=\"%s\" x2=\"%s\" y2=\"%s\" stroke=\"%s\" stroke-width=\"%s\"/>\n", svgFloat(x1), svgFloat(y1), svgFloat(x2), svgFloat(y2), template.HTMLEscapeString(color), svgFloat(width)))
+}
+
+func svg(p Pattern, opts *Option) ([]byte, error) {
+	cols := p.width + 1
+	rows := p.height + 1
+	gridWidth := cols * svgCell
+	gridHeight := rows * svgCell
+	symbols := p.chartSymbols(opts)
+	legend := p.legend(symbols)
+	centerX, centerY := p.center()
+	arrows := centerArrows(centerX, centerY, float64(p.width), float64(p.height), opts)
+	if len(arrows) > 0 {
+		// room for the right/bottom center markers
+		gridWidth += svgCell
+		gridHeight += svgCell
+	}
+	height := gridHeight + svgLegendLine*(len(legend)+2)
+	var b bytes.Buffer
+	b.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	b.WriteString(fmt.Sprintf("<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n", gridWidth, height, gridWidth, height))
+	b.WriteString(fmt.Sprintf("<rect x=\"0\" y=\"0\" width=\"%d\" height=\"%d\" fill=\"white\"/>\n", gridWidth, height))
+	b.WriteString("<g class=\"grid\">\n")
+	for idx := 1; idx <= cols; idx++ {
+		pos := float64(idx * svgCell)
+		svgLine(&b, pos, svgCell, pos, float64(rows*svgCell), svgGridColor, 0.5)
+	}
+	for idx := 1; idx <= rows; idx++ {
+		pos := float64(idx * svgCell)
+		svgLine(&b, svgCell, pos, float64(cols*svgCell), pos, svgGridColor, 0.5)
+	}
+	b.WriteString("</g>\n")
+	boldX := boldLines(p.width, opts)
+	boldY := boldLines(p.height, opts)
+	if len(boldX) > 0 || len(boldY) > 0 {
+		b.WriteString("<g class=\"bold-grid\">\n")
+		for _, x := range boldX {
+			pos := float64((x + 1) * svgCell)
+			svgLine(&b, pos, svgCell, pos, float64(rows*svgCell), "black", svgBoldWidth)
+		}
+		for _, y := range boldY {
+			pos := float64((y + 1) * svgCell)
+			svgLine(&b, svgCell, pos, float64(cols*svgCell), pos, "black", svgBoldWidth)
+		}
+		b.WriteString("</g>\n")
+	}
+	if len(arrows) > 0 {
+		b.WriteString("<g class=\"center\">\n")
+		for _, arrow := range arrows {
+			var points []string
+			for _, point := range arrow.polygon(svgCell, svgCell, svgCell) {
+				points = append(points, fmt.Sprintf("%s,%s", svgFloat(point[0]), svgFloat(point[1])))
+			}
+			b.WriteString(fmt.Sprintf("<polygon points=\"%s\" fill=\"black\"/>\n", strings.Join(points, " ")))
+		}
+		b.WriteString("</g>\n")
+	}
+	b.WriteString("<g class=\"labels\" font-family=\"Arial\" font-size=\"4\" text-anchor=\"middle\">\n")
+	for idx := 1; idx < cols; idx++ {
+		if !isAxisLabel(idx, opts) {
+			continue
+		}
+		center := idx*svgCell + svgCell/2
+		b.WriteString(fmt.Sprintf("<text x=\"%d\" y=\"%d\">%d</text>\n", center, svgCell-3, idx))
+	}
+	for idx := 1; idx < rows; idx++ {
+		if !isAxisLabel(idx, opts) {
+			continue
+		}
+		center := idx*svgCell + svgCell/2
+		b.WriteString(fmt.Sprintf("<text x=\"%d\" y=\"%d\">%d</text>\n", svgCell/2, center+2, idx))
+	}
+	b.WriteString("</g>\n")
+	b.WriteString("<g class=\"stitches\" stroke-linecap=\"round\">\n")
+	index := p.cellIndex()
+	for y := 1; y < rows; y++ {
+		for x := 1; x < cols; x++ {
+			for _, e := range index[cell{x: x, y: y}] {
+				if symbol, ok := symbols[e.color]; ok && e.mode == isXStitch {
+					b.WriteString(fmt.Sprintf("<text x=\"%d\" y=\"%d\" font-family=\"Arial\" font-size=\"7\" text-anchor=\"middle\">%s</text>\n", x*svgCell+svgCell/2, y*svgCell+svgCell-2, template.HTMLEscapeString(symbol)))
+					continue
+				}
+				width := svgStitchWidth
+				if isBackstitch(e.mode) {
+					width = svgLineWidth
+				}
+				left := float64(x * svgCell)
+				top := float64(y * svgCell)
+				for _, s := range stitchSegments(e.mode) {
+					svgLine(&b, left+s.x1*svgCell, top+s.y1*svgCell, left+s.x2*svgCell, top+s.y2*svgCell, e.color, width)
+				}
+			}
+		}
+	}
+	b.WriteString("</g>\n")
+	b.WriteString("<g class=\"backstitch\" stroke-linecap=\"round\">\n")
+	for _, l := range p.lines {
+		l.segments(func(from, to gridPoint) {
+			svgLine(&b, float64((from.x+1)*svgCell), float64((from.y+1)*svgCell), float64((to.x+1)*svgCell), float64((to.y+1)*svgCell), l.color, svgLineWidth)
+		})
+	}
+	b.WriteString("</g>\n")
+	b.WriteString("<g class=\"points\">\n")
+	for _, e := range p.entries {
+		if !isPoint(e.mode) {
+			continue
+		}
+		for _, c := range e.cells {
+			x := c.x * svgCell
+			y := c.y * svgCell
+			if e.mode == isBead {
+				b.WriteString(fmt.Sprintf("<circle cx=\"%d\" cy=\"%d\" r=\"%s\" fill=\"none\" stroke=\"%s\" stroke-width=\"%s\"/>\n", x, y, svgFloat(svgPointSize), template.HTMLEscapeString(e.color), svgFloat(svgLineWidth)))
+			} else {
+				b.WriteString(fmt.Sprintf("<circle cx=\"%d\" cy=\"%d\" r=\"%s\" fill=\"%s\"/>\n", x, y, svgFloat(svgPointSize), template.HTMLEscapeString(e.color)))
+			}
+		}
+	}
+	b.WriteString("</g>\n")
+	b.WriteString("<g class=\"legend\" font-family=\"Arial\" font-size=\"6\">\n")
+	top := gridHeight + svgLegendLine
+	for idx, line := range legend {
+		y := top + idx*svgLegendLine
+		if line.symbol != "" {
+			b.WriteString(fmt.Sprintf("<rect x=\"%d\" y=\"%d\" width=\"8\" height=\"8\" fill=\"white\" stroke=\"black\" stroke-width=\"0.25\"/>\n", svgCell, y))
+			b.WriteString(fmt.Sprintf("<text x=\"%d\" y=\"%d\" text-anchor=\"middle\">%s</text>\n", svgCell+4, y+6, template.HTMLEscapeString(line.symbol)))
+		} else {
+			b.WriteString(fmt.Sprintf("<rect x=\"%d\" y=\"%d\" width=\"8\" height=\"8\" fill=\"%s\" stroke=\"black\" stroke-width=\"0.25\"/>\n", svgCell, y, template.HTMLEscapeString(line.color.output)))
+		}
+		b.WriteString(fmt.Sprintf("<text x=\"%d\" y=\"%d\">%s</text>\n", 2*svgCell+2, y+7, template.HTMLEscapeString(line.text)))
+	}
+	b.WriteString("</g>\n")
+	b.WriteString("</svg>\n")
+	return b.Bytes(), nil
+}
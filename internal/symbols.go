@@ -0,0 +1,80 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// characters drawn by the ascii renderer for lines/intersections
+	reservedSymbols = asciiSep + "|-/\\+"
+)
+
+func validateSymbols(set string) error {
+	if set == "" {
+		return NewOptionsError("no symbols given")
+	}
+	seen := make(map[rune]struct{})
+	for _, r := range set {
+		if r <= ' ' || r > '~' || strings.ContainsRune(reservedSymbols, r) {
+			return NewOptionsError(fmt.Sprintf("invalid symbol: %q", r))
+		}
+		if _, ok := seen[r]; ok {
+			return NewOptionsError(fmt.Sprintf("duplicate symbol: %c", r))
+		}
+		seen[r] = struct{}{}
+	}
+	return nil
+}
+
+// symbols assigns a symbol to every color with full stitches, colors are
+// ordered by their input (not position in the pattern) so that a color
+// keeps its symbol as the pattern is edited. Colors beyond the symbol set
+// are not assigned a symbol.
+func (p Pattern) symbols(set string) map[string]string {
+	stitched := make(map[string]struct{})
+	for _, e := range p.entries {
+		if e.mode == isXStitch {
+			stitched[e.color] = struct{}{}
+		}
+	}
+	var colors []colorMap
+	for _, mapped := range p.colors {
+		if _, ok := stitched[mapped.output]; ok {
+			colors = append(colors, mapped)
+		}
+	}
+	sort.Slice(colors, func(i, j int) bool {
+		if colors[i].input == colors[j].input {
+			return colors[i].output < colors[j].output
+		}
+		return colors[i].input < colors[j].input
+	})
+	result := make(map[string]string)
+	for idx, mapped := range colors {
+		if idx >= len(set) {
+			break
+		}
+		result[mapped.output] = string(set[idx])
+	}
+	return result
+}
+
+// chartSymbols are the symbols for a symbol chart (nil when color charts are requested).
+func (p Pattern) chartSymbols(opts *Option) map[string]string {
+	if !opts.SymbolChart() {
+		return nil
+	}
+	return p.symbols(opts.Symbols())
+}
+
+// chartLines are the legend lines with the color line replaced by its symbol.
+func (c colorMap) chartLines(symbol string) []string {
+	lines := c.legendLines()
+	if symbol == "" || c.count == 0 {
+		return lines
+	}
+	lines[0] = fmt.Sprintf("symbol: %s => %s (count %d)", symbol, c.label(), c.count)
+	return lines
+}
@@ -22,6 +22,18 @@ const (
 	isTopRightBottomLeft = "trblline"
 	isHorizontalLine     = "hline"
 	isVerticalLine       = "vline"
+	isHalfTRBL           = "halftrbl"
+	isHalfTLBR           = "halftlbr"
+	isQuarterTL          = "quartertl"
+	isQuarterTR          = "quartertr"
+	isQuarterBL          = "quarterbl"
+	isQuarterBR          = "quarterbr"
+	isThreeQuarterTL     = "threequartertl"
+	isThreeQuarterTR     = "threequartertr"
+	isThreeQuarterBL     = "threequarterbl"
+	isThreeQuarterBR     = "threequarterbr"
+	isKnot               = "knot"
+	isBead               = "bead"
 	vLineSymbol          = "|"
 	hLineSymbol          = "---"
 	hLinePartial         = "-"
@@ -29,9 +41,21 @@ const (
 	// HTMLMode indicates html output.
 	HTMLMode = "html"
 	// ASCIIMode indicates ascii output.
-	ASCIIMode    = "ascii"
-	asciiSymbols = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ01234567890"
+	ASCIIMode = "ascii"
+	// SVGMode indicates svg output.
+	SVGMode = "svg"
+	// PDFMode indicates (multi-page) pdf output.
+	PDFMode = "pdf"
+	// PNGMode indicates png (raster preview) output.
+	PNGMode = "png"
+	// SummaryMode indicates a machine-readable (json) thread summary output.
+	SummaryMode = "summary"
+	// InfoMode indicates a finished size and fabric cut report output.
+	InfoMode     = "info"
+	asciiSymbols = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 	asciiSep     = "."
+	// fractional stitches (and knots/beads) are tracked per color and mode for ascii symbols
+	asciiFractionSep = "\t"
 )
 
 var (
@@ -48,10 +72,15 @@ type (
 	}
 	// HTMLPattern is the whole HTML pattern.
 	HTMLPattern struct {
-		Size    int
-		padding string
-		Cells   []Cell
-		Legend  []string
+		Width     int
+		Height    int
+		padding   string
+		Cells     []Cell
+		Legend    []string
+		Info      []string
+		Lines     []HTMLLine
+		Gridlines []HTMLLine
+		Markers   []HTMLMarker
 	}
 	cell struct {
 		x int
@@ -61,18 +90,30 @@ type (
 		cells []cell
 		mode  string
 		color string
+		// layer is the (committed) action of the entry, sources are where each cell came from
+		layer   int
+		sources []Position
 	}
 	colorMap struct {
-		input  string
-		output string
-		count  int
+		input    string
+		output   string
+		count    int
+		thread   flossThread
+		nearest  bool
+		distance float64
+		knots    int
+		beads    int
+		length   float64
+		blend    []flossColor
 	}
 	// Pattern is a backing pattern object.
 	Pattern struct {
-		size    int
+		width   int
+		height  int
 		pad     int
 		entries []entry
 		colors  []colorMap
+		lines   []polyline
 	}
 	asciiCell struct {
 		top     bool
@@ -81,6 +122,7 @@ type (
 		right   bool
 		found   bool
 		value   string
+		point   string
 		entries []entry
 	}
 )
@@ -90,13 +132,17 @@ func NewTemplateError(message string) error {
 	return stock.NewBasicCategoryError("template", message)
 }
 
-// NewPattern creates a new, initialized pattern.
-func NewPattern(size int) (Pattern, error) {
-	if size < 1 {
+// NewPattern creates a new, initialized pattern of width by height stitches.
+func NewPattern(width, height int) (Pattern, error) {
+	if width < 1 || height < 1 {
 		return Pattern{}, NewTemplateError("invalid size <= 0")
 	}
+	size := width
+	if height > size {
+		size = height
+	}
 	padding := len(fmt.Sprintf("%d", size)) + 2
-	return Pattern{pad: padding, size: size}, nil
+	return Pattern{pad: padding, width: width, height: height}, nil
 }
 
 func (o HTMLPattern) pad(val int) string {
@@ -107,17 +153,17 @@ func (o HTMLPattern) pad(val int) string {
 	return padded
 }
 
-func (o HTMLPattern) initCells(j Pattern) ([]Cell, error) {
+func (o HTMLPattern) initCells(j Pattern, symbols map[string]string, opts *Option) ([]Cell, error) {
 	var results []Cell
 	x := 0
-	for x < o.Size {
+	for x < o.Height {
 		y := 0
-		for y < o.Size {
+		for y < o.Width {
 			val := ""
-			if x == 0 {
+			if x == 0 && isAxisLabel(y, opts) {
 				val = fmt.Sprintf("%d", y)
 			}
-			if y == 0 {
+			if y == 0 && isAxisLabel(x, opts) {
 				val = fmt.Sprintf("%d", x)
 			}
 			if x == 0 && y == 0 {
@@ -126,7 +172,7 @@ func (o HTMLPattern) initCells(j Pattern) ([]Cell, error) {
 			cell := Cell{}
 			cell.ID = o.newID(y, x)
 			cell.Value = template.HTML(val)
-			style, hvLine, err := j.layout(y, x)
+			style, hvLine, err := j.layout(y, x, symbols)
 			if err != nil {
 				return results, err
 			}
@@ -148,13 +194,20 @@ func (o HTMLPattern) newID(x, y int) string {
 	return fmt.Sprintf("%s%s%s", left, gridLocation, right)
 }
 
-func (p Pattern) layout(x, y int) (string, string, error) {
+func (p Pattern) layout(x, y int, symbols map[string]string) (string, string, error) {
 	var style []string
 	vLineColor := ""
 	hLineColor := ""
 	tlbrColor := ""
 	trblColor := ""
+	symbol := ""
+	var fractions []entry
+	var backgrounds []string
 	for _, e := range p.entries {
+		if isPoint(e.mode) {
+			backgrounds = append(backgrounds, pointGradients(e, x, y)...)
+			continue
+		}
 		for _, c := range e.cells {
 			if c.x == x && c.y == y {
 				s := ""
@@ -180,7 +233,16 @@ func (p Pattern) layout(x, y int) (string, string, error) {
 				case isLeftEdge:
 					s = "border-left-style: solid; border-left-color: "
 				case isXStitch:
-					s = "background-color: "
+					if sym, ok := symbols[e.color]; ok {
+						symbol = sym
+					} else {
+						s = "background-color: "
+					}
+				default:
+					if isFractional(e.mode) {
+						fractions = append(fractions, e)
+						backgrounds = append(backgrounds, fractionGradients(e.mode, e.color)...)
+					}
 				}
 				if s != "" {
 					style = append(style, fmt.Sprintf("%s %s", s, e.color))
@@ -188,6 +250,12 @@ func (p Pattern) layout(x, y int) (string, string, error) {
 			}
 		}
 	}
+	if err := checkFractions(fractions); err != nil {
+		return "", "", err
+	}
+	if len(backgrounds) > 0 {
+		style = append(style, fmt.Sprintf("background-image: %s", strings.Join(backgrounds, ", ")))
+	}
 	sub := ""
 	hadHVLine := vLineColor != "" || hLineColor != ""
 	if hadHVLine {
@@ -218,6 +286,10 @@ func (p Pattern) layout(x, y int) (string, string, error) {
 			sub = colorLine("/", trblColor)
 		}
 	}
+	if sub == "" && symbol != "" {
+		style = append(style, fontSize)
+		sub = template.HTMLEscapeString(symbol)
+	}
 	return strings.Join(style, ";"), sub, nil
 }
 
@@ -227,27 +299,84 @@ func colorLine(symbol, color string) string {
 
 // ToHTMLPattern creates an HTML pattern.
 func (p Pattern) ToHTMLPattern() (HTMLPattern, error) {
+	return p.toHTMLPattern(&Option{})
+}
+
+func (p Pattern) toHTMLPattern(opts *Option) (HTMLPattern, error) {
 	padString := ""
 	padding := p.pad
 	for padding > 0 {
 		padString = fmt.Sprintf("0%s", padString)
 		padding--
 	}
-	obj := HTMLPattern{Size: p.size + 1, padding: padString}
-	cells, err := obj.initCells(p)
+	obj := HTMLPattern{Width: p.width + 1, Height: p.height + 1, padding: padString}
+	symbols := p.chartSymbols(opts)
+	cells, err := obj.initCells(p, symbols, opts)
 	if err != nil {
 		return obj, err
 	}
 	obj.Cells = cells
+	obj.Lines = p.htmlLines()
+	obj.Gridlines, obj.Markers = p.htmlGrid(opts)
 	var legend []string
 	for _, mapped := range p.colors {
-		legend = append(legend, fmt.Sprintf("color: %s (count %d)", mapped.input, mapped.count))
+		legend = append(legend, mapped.chartLines(symbols[mapped.output])...)
 	}
 	sort.Strings(legend)
+	if opts.EstimateThread() {
+		legend = append(legend, threadHeader(opts))
+		for _, usage := range p.usage(opts) {
+			legend = append(legend, usage.String())
+		}
+	}
 	obj.Legend = legend
+	if opts.InfoHTML() {
+		obj.Info = p.info(opts)
+	}
 	return obj, nil
 }
 
+func (c colorMap) legendLines() []string {
+	var lines []string
+	if c.count > 0 || (c.knots == 0 && c.beads == 0 && c.length == 0) {
+		lines = append(lines, fmt.Sprintf("color: %s (count %d)", c.label(), c.count))
+	}
+	if c.knots > 0 {
+		lines = append(lines, fmt.Sprintf("knot: %s (count %d)", c.label(), c.knots))
+	}
+	if c.beads > 0 {
+		lines = append(lines, fmt.Sprintf("bead: %s (count %d)", c.label(), c.beads))
+	}
+	if c.length > 0 {
+		lines = append(lines, fmt.Sprintf("backstitch: %s (length %.2f)", c.label(), c.length))
+	}
+	return lines
+}
+
+func (c colorMap) label() string {
+	if len(c.blend) > 0 {
+		var components []string
+		for _, component := range c.blend {
+			if component.thread.code == "" {
+				components = append(components, component.input)
+				continue
+			}
+			components = append(components, fmt.Sprintf("%s %s %s", component.thread.brand, component.thread.code, component.thread.name))
+		}
+		return fmt.Sprintf("%s [blend: %s]", c.input, strings.Join(components, " + "))
+	}
+	if c.thread.code == "" {
+		return c.input
+	}
+	if c.nearest {
+		return fmt.Sprintf("%s [nearest: %s, %s %s, delta-e: %.2f]", c.input, c.thread.name, c.thread.brand, c.thread.code, c.distance)
+	}
+	if c.input == c.thread.name {
+		return fmt.Sprintf("%s [%s %s]", c.input, c.thread.brand, c.thread.code)
+	}
+	return fmt.Sprintf("%s [%s]", c.input, c.thread.name)
+}
+
 func (p Pattern) findASCIIEdges(y, x int) asciiCell {
 	obj := asciiCell{}
 	for _, entry := range p.entries {
@@ -272,16 +401,41 @@ func (p Pattern) findASCIIEdges(y, x int) asciiCell {
 }
 
 func ascii(p Pattern, opts *Option) ([]byte, error) {
-	size := p.size + 2
+	width := p.width + 2
+	height := p.height + 2
 	row := 0
 	var array [][]asciiCell
-	colorMap := make(map[string]string)
+	// full stitches use the stable symbols, fractional stitches and knots/beads
+	// take the remaining symbols as they are found
+	set := opts.Symbols()
+	colorMap := p.symbols(set)
+	used := make(map[string]struct{})
+	for _, symbol := range colorMap {
+		used[symbol] = struct{}{}
+	}
 	colorPos := 0
+	fractionKeys := make(map[string]entry)
+	nextSymbol := func(key string) string {
+		if val, ok := colorMap[key]; ok {
+			return val
+		}
+		symbol := ""
+		for colorPos < len(set) {
+			candidate := string(set[colorPos])
+			colorPos++
+			if _, ok := used[candidate]; !ok {
+				symbol = candidate
+				break
+			}
+		}
+		colorMap[key] = symbol
+		return symbol
+	}
 	var warnings []string
-	for row <= size {
+	for row <= height {
 		col := 0
 		array = append(array, []asciiCell{})
-		for col <= size {
+		for col <= width {
 			self := p.findASCIIEdges(row, col)
 			above := p.findASCIIEdges(row-1, col)
 			below := p.findASCIIEdges(row+1, col)
@@ -299,6 +453,8 @@ func ascii(p Pattern, opts *Option) ([]byte, error) {
 				hasTRBL := false
 				color := ""
 				isStitch := false
+				var fractions []entry
+				var points []entry
 				for _, entry := range self.entries {
 					switch entry.mode {
 					case isTopLeftBottomRight:
@@ -328,7 +484,25 @@ func ascii(p Pattern, opts *Option) ([]byte, error) {
 					case isXStitch:
 						color = entry.color
 						isStitch = true
+					default:
+						if isFractional(entry.mode) {
+							fractions = append(fractions, entry)
+						}
+						if isPoint(entry.mode) {
+							points = append(points, entry)
+						}
+					}
+				}
+				if len(points) > 0 {
+					if len(points) > 1 {
+						warnings = append(warnings, "cannot have multiple knots/beads at an ASCII intersection")
 					}
+					key := fmt.Sprintf("%s%s%s", points[0].color, asciiFractionSep, points[0].mode)
+					fractionKeys[key] = points[0]
+					self.point = nextSymbol(key)
+				}
+				if err := checkFractions(fractions); err != nil {
+					return nil, err
 				}
 				if hasVLine && hasHLine {
 					self.value = "+"
@@ -340,17 +514,20 @@ func ascii(p Pattern, opts *Option) ([]byte, error) {
 					if self.value != " " {
 						warnings = append(warnings, "cannot have stitch+line in ASCII pattern")
 					}
-					symbol := ""
-					if val, ok := colorMap[color]; ok {
-						symbol = val
-					} else {
-						if colorPos < len(asciiSymbols) {
-							symbol = fmt.Sprintf("%c", asciiSymbols[colorPos])
-							colorPos++
-						}
-						colorMap[color] = symbol
+					if len(fractions) > 0 {
+						warnings = append(warnings, "cannot have stitch+fractional stitch in ASCII pattern")
+					}
+					self.value = nextSymbol(color)
+				} else if len(fractions) > 0 {
+					if self.value != " " {
+						warnings = append(warnings, "cannot have stitch+line in ASCII pattern")
 					}
-					self.value = symbol
+					if len(fractions) > 1 {
+						warnings = append(warnings, "cannot have multiple fractional stitches in ASCII pattern")
+					}
+					key := fmt.Sprintf("%s%s%s", fractions[0].color, asciiFractionSep, fractions[0].mode)
+					fractionKeys[key] = fractions[0]
+					self.value = nextSymbol(key)
 				}
 			}
 			array[row] = append(array[row], self)
@@ -360,25 +537,39 @@ func ascii(p Pattern, opts *Option) ([]byte, error) {
 	}
 
 	var raw bytes.Buffer
-	for _, row := range array {
+	overlay := p.asciiOverlay()
+	write := func(x, y int, value string) {
+		// backstitch lines only trace over otherwise empty characters
+		if glyph, ok := overlay[gridPoint{x: x, y: y}]; ok && (value == asciiSep || value == " ") {
+			value = glyph
+		}
+		raw.WriteString(value)
+	}
+	for rowIdx, row := range array {
 		raw.WriteString("\n")
 		for _, idx := range []int{0, 1} {
-			for _, cell := range row {
+			y := 2*rowIdx + idx
+			for colIdx, cell := range row {
+				x := 2 * colIdx
 				switch idx {
 				case 0:
-					raw.WriteString(asciiSep)
+					if cell.point != "" {
+						write(x, y, cell.point)
+					} else {
+						write(x, y, asciiSep)
+					}
 					if cell.top {
-						raw.WriteString("-")
+						write(x+1, y, "-")
 					} else {
-						raw.WriteString(" ")
+						write(x+1, y, " ")
 					}
 				case 1:
 					if cell.left {
-						raw.WriteString("|")
+						write(x, y, "|")
 					} else {
-						raw.WriteString(" ")
+						write(x, y, " ")
 					}
-					raw.WriteString(cell.value)
+					write(x+1, y, cell.value)
 				}
 			}
 			if idx == 0 {
@@ -418,19 +609,40 @@ func ascii(p Pattern, opts *Option) ([]byte, error) {
 	for k, v := range colorMap {
 		count := 0
 		input := k
+		fraction, isFraction := fractionKeys[k]
+		if isFraction {
+			input = fraction.color
+			count = p.countMode(fraction.color, fraction.mode)
+		}
 		for _, color := range p.colors {
-			if color.output == k {
-				count = color.count
-				input = color.input
+			if color.output == input {
+				if !isFraction {
+					count = color.count
+				}
+				input = color.label()
 				break
 			}
 		}
+		if isFraction {
+			input = fmt.Sprintf("%s (%s)", input, fraction.mode)
+		}
 		legend = append(legend, (fmt.Sprintf("color: %s => %s (count: %d)\n", v, input, count)))
 	}
+	for _, color := range p.colors {
+		if color.length > 0 {
+			legend = append(legend, fmt.Sprintf("backstitch: %s (length: %.2f)\n", color.label(), color.length))
+		}
+	}
 	sort.Strings(legend)
 	for _, line := range legend {
 		b.WriteString(line)
 	}
+	if opts.EstimateThread() {
+		b.WriteString(fmt.Sprintf("%s\n", threadHeader(opts)))
+		for _, usage := range p.usage(opts) {
+			b.WriteString(fmt.Sprintf("%s\n", usage.String()))
+		}
+	}
 	sort.Strings(warnings)
 	tracked := make(map[string]int)
 	for _, warning := range warnings {
@@ -457,15 +669,25 @@ func reverse(array []string) []string {
 func Build(p Pattern, mode string, options *Option) ([]byte, error) {
 	switch mode {
 	case HTMLMode:
-		return html(p)
+		return html(p, options)
 	case ASCIIMode:
 		return ascii(p, options)
+	case SVGMode:
+		return svg(p, options)
+	case PDFMode:
+		return pdf(p, options)
+	case PNGMode:
+		return raster(p, options)
+	case SummaryMode:
+		return summary(p, options)
+	case InfoMode:
+		return info(p, options)
 	}
 	return nil, NewTemplateError(fmt.Sprintf("unknown mode: %s", mode))
 }
 
-func html(p Pattern) ([]byte, error) {
-	obj, err := p.ToHTMLPattern()
+func html(p Pattern, opts *Option) ([]byte, error) {
+	obj, err := p.toHTMLPattern(opts)
 	if err != nil {
 		return nil, err
 	}
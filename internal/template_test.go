@@ -1,18 +1,24 @@
 package internal_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"html/template"
+	"image/color"
+	"image/png"
+	"strings"
 	"testing"
 
 	"voidedtech.com/gxs/internal"
 )
 
 func TestNewPattern(t *testing.T) {
-	_, err := internal.NewPattern(0)
+	_, err := internal.NewPattern(0, 1)
 	if err == nil {
 		t.Error("invalid request, size is invalid")
 	}
-	_, err = internal.NewPattern(1)
+	_, err = internal.NewPattern(1, 1)
 	if err != nil {
 		t.Error("valid JSON result")
 	}
@@ -28,12 +34,12 @@ func checkCell(t *testing.T, cell internal.Cell, expectedID, expectedValue strin
 }
 
 func TestToHTMLPattern(t *testing.T) {
-	j, err := internal.NewPattern(2)
+	j, err := internal.NewPattern(2, 2)
 	if err != nil {
 		t.Error("valid JSON result")
 	}
 	pattern, err := j.ToHTMLPattern()
-	if err != nil || pattern.Size != 3 {
+	if err != nil || pattern.Width != 3 || pattern.Height != 3 {
 		t.Error("invalid conversion")
 	}
 	if len(pattern.Cells) != 9 {
@@ -41,17 +47,17 @@ func TestToHTMLPattern(t *testing.T) {
 	}
 	checkCell(t, pattern.Cells[0], "000x000", "")
 	checkCell(t, pattern.Cells[1], "001x000", "1")
-	checkCell(t, pattern.Cells[2], "002x000", "2")
+	checkCell(t, pattern.Cells[2], "002x000", "")
 	checkCell(t, pattern.Cells[3], "000x001", "1")
 	checkCell(t, pattern.Cells[4], "001x001", "")
 	checkCell(t, pattern.Cells[5], "002x001", "")
-	checkCell(t, pattern.Cells[6], "000x002", "2")
+	checkCell(t, pattern.Cells[6], "000x002", "")
 	checkCell(t, pattern.Cells[7], "001x002", "")
 	checkCell(t, pattern.Cells[8], "002x002", "")
 }
 
 func TestHTMLBuild(t *testing.T) {
-	j, err := internal.NewPattern(1)
+	j, err := internal.NewPattern(1, 1)
 	if err != nil {
 		t.Error("pattern is valid")
 	}
@@ -62,7 +68,7 @@ func TestHTMLBuild(t *testing.T) {
 }
 
 func TestASCIIBuild(t *testing.T) {
-	j, err := internal.NewPattern(1)
+	j, err := internal.NewPattern(1, 1)
 	if err != nil {
 		t.Error("pattern is valid")
 	}
@@ -71,3 +77,551 @@ func TestASCIIBuild(t *testing.T) {
 		t.Error("invalid building result")
 	}
 }
+
+func TestSVGBuild(t *testing.T) {
+	j, err := internal.NewPattern(1, 1)
+	if err != nil {
+		t.Error("pattern is valid")
+	}
+	b, err := internal.Build(j, "svg", &internal.Option{})
+	if err != nil || len(b) == 0 {
+		t.Error("invalid building result")
+	}
+	p, pErr := internal.Parse([]byte(`
+palette => {
+	x => red
+	y => dmc:310
+}
+mode => {xstitch}
+pattern => {x}
+action => {commit}
+backstitch => {y => 0,0 1,1}
+`))
+	if pErr != nil {
+		t.Fatalf("pattern is valid: %v", pErr.Error)
+	}
+	b, err = internal.Build(p, internal.SVGMode, &internal.Option{})
+	if err != nil {
+		t.Fatalf("invalid svg: %v", err)
+	}
+	svg := string(b)
+	for _, expect := range []string{
+		`<svg xmlns="http://www.w3.org/2000/svg" width="30" height="78" viewBox="0 0 30 78">`,
+		`<line x1="11.5" y1="11.5" x2="18.5" y2="18.5" stroke="rgb(199, 43, 59)" stroke-width="1.8"/>`,
+		`<line x1="18.5" y1="11.5" x2="11.5" y2="18.5" stroke="rgb(199, 43, 59)" stroke-width="1.8"/>`,
+		`<g class="backstitch" stroke-linecap="round">
+<line x1="10" y1="10" x2="20" y2="20" stroke="rgb(0, 0, 0)" stroke-width="1.2"/>`,
+		`<text x="22" y="49">backstitch: dmc:310 [black] (length 1.41)</text>`,
+		`<text x="22" y="61">color: red [dmc 321] (count 1)</text>`,
+	} {
+		if !strings.Contains(svg, expect) {
+			t.Errorf("missing %s in: %s", expect, svg)
+		}
+	}
+	if !strings.HasSuffix(svg, "</svg>\n") {
+		t.Error("svg root not closed")
+	}
+}
+
+func TestPDFBuild(t *testing.T) {
+	row := strings.Repeat("x", 100)
+	p, pErr := internal.Parse([]byte(fmt.Sprintf(`
+palette => {
+	x => red
+}
+mode => {xstitch}
+pattern => {
+	%s
+}
+action => {commit}
+`, row)))
+	if pErr != nil {
+		t.Error("pattern is valid")
+	}
+	b, err := internal.Build(p, "pdf", &internal.Option{})
+	if err != nil || !bytes.HasPrefix(b, []byte("%PDF-")) {
+		t.Error("invalid building result")
+	}
+	if count := bytes.Count(b, []byte("/Type /Page ")); count != 3 {
+		t.Errorf("invalid page count: %d", count)
+	}
+	o := &internal.Option{}
+	if err := o.Set("pdf-overlap=100"); err != nil {
+		t.Error("valid option")
+	}
+	if _, err := internal.Build(p, "pdf", o); err == nil || err.Error() != "template: pdf cell size and overlap leave no room on a page" {
+		t.Error("overlap too large")
+	}
+}
+
+func TestPNGBuild(t *testing.T) {
+	p, pErr := internal.Parse([]byte(`
+palette => {
+	x => #ff0000
+	y => rgb(0, 0, 255)
+	z => pink
+	- => NONE
+}
+mode => {xstitch}
+pattern => {
+	xyz
+}
+action => {commit}
+mode => {bottomedge}
+pattern => {
+	-z
+}
+action => {commit}
+`))
+	if pErr != nil {
+		t.Error("pattern is valid")
+	}
+	o := &internal.Option{}
+	if err := o.Set("png-cell-size=10"); err != nil {
+		t.Error("valid option")
+	}
+	b, err := internal.Build(p, "png", o)
+	if err != nil {
+		t.Error("invalid building result")
+	}
+	img, err := png.Decode(bytes.NewReader(b))
+	if err != nil {
+		t.Error("invalid png")
+	}
+	if img.Bounds().Dx() != 30 || img.Bounds().Dy() != 10 {
+		t.Error("invalid size")
+	}
+	check := func(x, y int, expect color.RGBA) {
+		r, g, b, _ := img.At(x, y).RGBA()
+		if uint8(r>>8) != expect.R || uint8(g>>8) != expect.G || uint8(b>>8) != expect.B {
+			t.Errorf("invalid color at %dx%d", x, y)
+		}
+	}
+	check(5, 5, color.RGBA{R: 255})
+	check(15, 5, color.RGBA{B: 255})
+	check(25, 5, color.RGBA{R: 255, G: 192, B: 203})
+	check(15, 9, color.RGBA{R: 255, G: 192, B: 203})
+	p, _ = internal.Parse([]byte(`
+palette => {x => notacolor}
+mode => {xstitch}
+pattern => {x}
+action => {commit}
+`))
+	if _, err := internal.Build(p, "png", o); err == nil || err.Error() != "color: unknown color: notacolor" {
+		t.Error("unknown color")
+	}
+}
+
+func TestThreadEstimate(t *testing.T) {
+	p, pErr := internal.Parse([]byte(`
+palette => {
+	x => dmc:310
+	y => red
+}
+mode => {xstitch}
+pattern => {
+	xxxx
+	xxxx
+}
+action => {commit}
+mode => {bottomedge}
+pattern => {
+	yyyy
+}
+action => {commit}
+`))
+	if pErr != nil {
+		t.Errorf("invalid pattern: %v", pErr.Error)
+	}
+	opts := &internal.Option{}
+	b, err := internal.Build(p, internal.SummaryMode, opts)
+	if err != nil {
+		t.Errorf("invalid summary: %v", err)
+	}
+	var summary internal.ThreadSummary
+	if err := json.Unmarshal(b, &summary); err != nil {
+		t.Errorf("invalid json: %v", err)
+	}
+	if summary.Fabric != "14ct" || summary.Strands != 2 || len(summary.Colors) != 2 {
+		t.Fatalf("invalid summary: %v", summary)
+	}
+	black := summary.Colors[0]
+	if black.Color != "dmc:310" || black.Floss != "dmc 310" || black.Stitches != 8 || black.Skeins != 1 || black.Meters != 0.17 {
+		t.Errorf("invalid usage: %v", black)
+	}
+	red := summary.Colors[1]
+	if red.Color != "red" || red.Stitches != 0 || red.Backstitch != 4 {
+		t.Errorf("invalid usage: %v", red)
+	}
+	b, err = internal.Build(p, internal.ASCIIMode, opts)
+	if err != nil || strings.Contains(string(b), "thread estimate") {
+		t.Error("no estimate without fabric")
+	}
+	if err := opts.Set("fabric=28over2"); err != nil {
+		t.Error("valid fabric")
+	}
+	b, err = internal.Build(p, internal.ASCIIMode, opts)
+	if err != nil {
+		t.Errorf("invalid ascii: %v", err)
+	}
+	if !strings.Contains(string(b), "thread estimate (28ct over 2 fabric, 2 strand(s)):\nthread: dmc:310 [black] => 8 stitches") {
+		t.Errorf("missing estimate: %s", string(b))
+	}
+}
+
+func TestThreadLegend(t *testing.T) {
+	p, pErr := internal.Parse([]byte(`
+palette => {
+	x => red
+	- => NONE
+}
+mode => {xstitch}
+pattern => {
+	xx
+}
+action => {commit}
+mode => {halftlbr}
+pattern => {
+	--x
+}
+action => {commit}
+mode => {knot}
+pattern => {
+	x-x
+}
+action => {commit}
+`))
+	if pErr != nil {
+		t.Fatalf("invalid pattern: %v", pErr.Error)
+	}
+	opts := &internal.Option{}
+	if err := opts.Set("fabric=14ct"); err != nil {
+		t.Error("valid fabric")
+	}
+	b, err := internal.Build(p, internal.SummaryMode, opts)
+	if err != nil {
+		t.Errorf("invalid summary: %v", err)
+	}
+	var summary internal.ThreadSummary
+	if err := json.Unmarshal(b, &summary); err != nil || len(summary.Colors) != 1 {
+		t.Fatalf("invalid summary: %s", string(b))
+	}
+	red := summary.Colors[0]
+	expect := fmt.Sprintf("thread: red [dmc 321] => 2 stitches, 1 partial, 2 knots, 0.00 cells backstitch, %.2fm, 1 skein(s)", red.Meters)
+	if red.Stitches != 2 || red.Partial != 1 || red.Knots != 2 {
+		t.Errorf("invalid usage: %v", red)
+	}
+	for mode, line := range map[string]string{internal.ASCIIMode: expect, internal.HTMLMode: template.HTMLEscapeString(expect)} {
+		b, err := internal.Build(p, mode, opts)
+		if err != nil || !strings.Contains(string(b), line) {
+			t.Errorf("legend should match the summary (%s): %s", line, string(b))
+		}
+	}
+}
+
+func TestInfo(t *testing.T) {
+	p, err := internal.NewPattern(28, 28)
+	if err != nil {
+		t.Error("valid pattern")
+	}
+	opts := &internal.Option{}
+	b, err := internal.Build(p, internal.InfoMode, opts)
+	if err != nil {
+		t.Errorf("invalid info: %v", err)
+	}
+	expect := `design: 28x28 stitches
+fabric: 14ct
+finished size: 2.00in x 2.00in (5.08cm x 5.08cm)
+fabric cut (3.00in margin): 8.00in x 8.00in (20.32cm x 20.32cm)
+center stitch: x=14.5, y=14.5
+`
+	if string(b) != expect {
+		t.Errorf("invalid info: %s", string(b))
+	}
+	if err := opts.Set("fabric=28over2"); err != nil {
+		t.Error("valid fabric")
+	}
+	if err := opts.Set("margin=1"); err != nil {
+		t.Error("valid margin")
+	}
+	b, err = internal.Build(p, internal.InfoMode, opts)
+	if err != nil || !strings.Contains(string(b), "fabric cut (1.00in margin): 4.00in x 4.00in (10.16cm x 10.16cm)") {
+		t.Errorf("invalid info: %s", string(b))
+	}
+	b, err = internal.Build(p, internal.HTMLMode, opts)
+	if err != nil || strings.Contains(string(b), "finished size") {
+		t.Error("no info header by default")
+	}
+	if err := opts.Set("html-info=true"); err != nil {
+		t.Error("valid html-info")
+	}
+	b, err = internal.Build(p, internal.HTMLMode, opts)
+	if err != nil || !strings.Contains(string(b), "finished size: 2.00in x 2.00in (5.08cm x 5.08cm)<br />") {
+		t.Error("missing info header")
+	}
+}
+
+func TestRectangularPattern(t *testing.T) {
+	p, pErr := internal.Parse([]byte(`
+palette => {
+	x => red
+}
+mode => {xstitch}
+pattern => {
+	xxxxx
+	xxxxx
+}
+action => {commit}
+`))
+	if pErr != nil {
+		t.Error("pattern is valid")
+	}
+	pattern, err := p.ToHTMLPattern()
+	if err != nil || pattern.Width != 6 || pattern.Height != 3 || len(pattern.Cells) != 18 {
+		t.Error("invalid html pattern")
+	}
+	checkCell(t, pattern.Cells[5], "005x000", "")
+	checkCell(t, pattern.Cells[6], "000x001", "1")
+	b, err := internal.Build(p, internal.ASCIIMode, &internal.Option{})
+	if err != nil {
+		t.Error("invalid building result")
+	}
+	grid := strings.Split(string(b), "---")[0]
+	if rows := strings.Count(grid, "\n. "); rows != 4 {
+		t.Errorf("invalid ascii rows: %d", rows)
+	}
+	b, err = internal.Build(p, internal.InfoMode, &internal.Option{})
+	if err != nil || !strings.HasPrefix(string(b), "design: 5x2 stitches") || !strings.Contains(string(b), "center stitch: x=3, y=1.5") {
+		t.Errorf("invalid info: %s", string(b))
+	}
+}
+
+func TestFractionalStitches(t *testing.T) {
+	p, pErr := internal.Parse([]byte(`
+palette => {
+	r => red
+	b => blue
+	- => NONE
+}
+mode => {quartertl}
+pattern => {
+	rr
+}
+action => {commit}
+mode => {quarterbr}
+pattern => {
+	-b
+}
+action => {commit}
+mode => {threequartertr}
+pattern => {
+	--b
+}
+action => {commit}
+`))
+	if pErr != nil {
+		t.Errorf("pattern is valid: %v", pErr.Error)
+	}
+	b, err := internal.Build(p, internal.ASCIIMode, &internal.Option{})
+	if err != nil {
+		t.Errorf("invalid ascii: %v", err)
+	}
+	ascii := string(b)
+	for _, expect := range []string{"   a a b    \n", "color: a => red [dmc 321] (quartertl) (count: 2)", "color: b => blue (threequartertr) (count: 1)", "WARN: cannot have multiple fractional stitches in ASCII pattern [1]"} {
+		if !strings.Contains(ascii, expect) {
+			t.Errorf("missing %s in: %s", expect, ascii)
+		}
+	}
+	b, err = internal.Build(p, internal.HTMLMode, &internal.Option{})
+	if err != nil || !strings.Contains(string(b), "linear-gradient(to top left, blue 25%, transparent 25%)") {
+		t.Error("missing quarter stitch")
+	}
+	p, pErr = internal.Parse([]byte(`
+palette => {
+	r => red
+	b => blue
+}
+mode => {halftrbl}
+pattern => {
+	r
+}
+action => {commit}
+mode => {halftlbr}
+pattern => {
+	b
+}
+action => {commit}
+`))
+	if pErr != nil {
+		t.Errorf("pattern is valid: %v", pErr.Error)
+	}
+	for _, mode := range []string{internal.ASCIIMode, internal.HTMLMode} {
+		if _, err := internal.Build(p, mode, &internal.Option{}); err == nil || err.Error() != "template: fractional stitches overlap within a cell" {
+			t.Errorf("halves overlap: %v", err)
+		}
+	}
+}
+
+func TestKnots(t *testing.T) {
+	p, pErr := internal.Parse([]byte(`
+palette => {
+	x => red
+	k => blue
+	- => NONE
+}
+mode => {xstitch}
+pattern => {
+	xx
+}
+action => {commit}
+mode => {knot}
+pattern => {
+	k-k
+	--k
+}
+action => {commit}
+`))
+	if pErr != nil {
+		t.Errorf("pattern is valid: %v", pErr.Error)
+	}
+	b, err := internal.Build(p, internal.InfoMode, &internal.Option{})
+	if err != nil || !strings.HasPrefix(string(b), "design: 2x1 stitches") {
+		t.Errorf("knots are on intersections: %s", string(b))
+	}
+	b, err = internal.Build(p, internal.ASCIIMode, &internal.Option{})
+	if err != nil {
+		t.Errorf("invalid ascii: %v", err)
+	}
+	for _, expect := range []string{"\n. b . b . \n", "\n. . . b . \n", "color: b => blue (knot) (count: 3)", "color: a => red [dmc 321] (count: 2)"} {
+		if !strings.Contains(string(b), expect) {
+			t.Errorf("missing %s in: %s", expect, string(b))
+		}
+	}
+	b, err = internal.Build(p, internal.HTMLMode, &internal.Option{})
+	if err != nil || !strings.Contains(string(b), "knot: blue (count 3)") || !strings.Contains(string(b), "color: red [dmc 321] (count 2)") {
+		t.Error("invalid legend")
+	}
+	if !strings.Contains(string(b), "radial-gradient(circle at bottom right, blue 3px, transparent 3px)") {
+		t.Error("missing knot")
+	}
+}
+
+func TestPointsOnly(t *testing.T) {
+	for _, mode := range []string{"knot", "bead"} {
+		for _, rows := range []string{"xx", "x\nx"} {
+			p, pErr := internal.Parse([]byte(fmt.Sprintf("palette => {x => millhill:00123}\nmode => {%s}\npattern => {\n%s\n}\naction => {commit}\n", mode, rows)))
+			if pErr != nil {
+				t.Errorf("pattern is valid: %v", pErr.Error)
+				continue
+			}
+			b, err := internal.Build(p, internal.InfoMode, &internal.Option{})
+			if err != nil || !strings.HasPrefix(string(b), "design: 1x1 stitches") {
+				t.Errorf("a single %s row/column needs a cell: %s %v", mode, string(b), err)
+			}
+		}
+	}
+}
+
+func TestSymbolChart(t *testing.T) {
+	p, pErr := internal.Parse([]byte(`
+palette => {
+	r => red
+	b => dmc:310
+	z => NONE
+}
+mode => {xstitch}
+pattern => {
+	rrb
+}
+action => {commit}
+mode => {halftlbr}
+pattern => {
+	zzz
+	zzr
+}
+action => {commit}
+`))
+	if pErr != nil {
+		t.Errorf("pattern is valid: %v", pErr.Error)
+	}
+	opts := &internal.Option{}
+	if err := opts.Set("symbol-chart=true"); err != nil {
+		t.Error("valid option")
+	}
+	if err := opts.Set("symbols=#@"); err != nil {
+		t.Error("valid option")
+	}
+	b, err := internal.Build(p, internal.HTMLMode, opts)
+	if err != nil {
+		t.Errorf("invalid html: %v", err)
+	}
+	html := string(b)
+	for _, expect := range []string{"symbol: # =&gt; dmc:310 [black] (count 1)", "symbol: @ =&gt; red [dmc 321] (count 3)", "id=\"001x001\">@</div>", "id=\"003x001\">#</div>"} {
+		if !strings.Contains(html, expect) {
+			t.Errorf("missing %s in: %s", expect, html)
+		}
+	}
+	if strings.Contains(html, "background-color:  rgb(199") {
+		t.Error("symbol chart should not color cells")
+	}
+	b, err = internal.Build(p, internal.SVGMode, opts)
+	if err != nil || !strings.Contains(string(b), ">@</text>") || !strings.Contains(string(b), "symbol: # =&gt; dmc:310 [black] (count 1)") {
+		t.Errorf("invalid svg: %s", string(b))
+	}
+	b, err = internal.Build(p, internal.ASCIIMode, opts)
+	if err != nil || !strings.Contains(string(b), "color: # => dmc:310 [black] (count: 1)") || !strings.Contains(string(b), "color: @ => red [dmc 321] (count: 3)") {
+		t.Errorf("ascii uses the same symbols: %s", string(b))
+	}
+	b, err = internal.Build(p, internal.PDFMode, opts)
+	if err != nil || !bytes.HasPrefix(b, []byte("%PDF-")) {
+		t.Error("invalid pdf")
+	}
+}
+
+func TestChartGrid(t *testing.T) {
+	p, err := internal.NewPattern(21, 12)
+	if err != nil {
+		t.Error("pattern is valid")
+	}
+	b, err := internal.Build(p, internal.HTMLMode, &internal.Option{})
+	if err != nil {
+		t.Errorf("invalid html: %v", err)
+	}
+	html := string(b)
+	for _, expect := range []string{
+		"id=\"0010x0000\">10</div>",
+		"id=\"0020x0000\">20</div>",
+		"id=\"0000x0010\">10</div>",
+		"id=\"0001x0000\">1</div>",
+		"id=\"0002x0000\"></div>",
+		"<line x1=\"120.5\" y1=\"10.5\" x2=\"120.5\" y2=\"142.5\" stroke=\"black\" stroke-width=\"1\"/>",
+		"<line x1=\"10.5\" y1=\"120.5\" x2=\"241.5\" y2=\"120.5\" stroke=\"black\" stroke-width=\"1\"/>",
+		"<polygon points=\"126,10.5 121.6,1.7 130.4,1.7\" fill=\"black\"/>",
+		"<polygon points=\"241.5,76.5 250.3,72.1 250.3,80.9\" fill=\"black\"/>",
+	} {
+		if !strings.Contains(html, expect) {
+			t.Errorf("missing %s", expect)
+		}
+	}
+	opts := &internal.Option{}
+	for _, option := range []string{"bold-gridlines=false", "center-markers=false", "axis-tens=false"} {
+		if err := opts.Set(option); err != nil {
+			t.Error("valid option")
+		}
+	}
+	b, err = internal.Build(p, internal.HTMLMode, opts)
+	if err != nil || !strings.Contains(string(b), "id=\"0002x0000\">2</div>") || strings.Contains(string(b), "<svg") {
+		t.Error("grid options should be switchable")
+	}
+	b, err = internal.Build(p, internal.SVGMode, &internal.Option{})
+	if err != nil || !strings.Contains(string(b), "<g class=\"bold-grid\">") || strings.Count(string(b), "<polygon") != 4 {
+		t.Errorf("invalid svg: %s", string(b))
+	}
+	b, err = internal.Build(p, internal.SVGMode, opts)
+	if err != nil || strings.Contains(string(b), "<g class=\"bold-grid\">") || strings.Contains(string(b), "<polygon") {
+		t.Errorf("grid options should be switchable: %s", string(b))
+	}
+	if err := opts.Set("axis-tens=maybe"); err == nil || err.Error() != "options: invalid boolean value" {
+		t.Error("bad option")
+	}
+}
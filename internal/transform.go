@@ -0,0 +1,130 @@
+package internal
+
+const (
+	commitAction    = "commit"
+	mirrorXAction   = "mirror-x"
+	mirrorYAction   = "mirror-y"
+	rotate90Action  = "rotate90"
+	rotate180Action = "rotate180"
+	transposeAction = "transpose"
+)
+
+type (
+	patternTransform struct {
+		// position moves a cell within a width by height pattern (0-based), returning the new position and size
+		position func(x, y, width, height int) (int, int, int, int)
+		modes    map[string]string
+	}
+)
+
+var (
+	transforms = map[string]patternTransform{
+		// mirror-x reverses the columns (left to right)
+		mirrorXAction: {
+			position: func(x, y, width, height int) (int, int, int, int) {
+				return width - 1 - x, y, width, height
+			},
+			modes: swapModes(
+				[]string{isLeftEdge, isRightEdge},
+				[]string{isTopLeftBottomRight, isTopRightBottomLeft},
+				[]string{isHalfTLBR, isHalfTRBL},
+				[]string{isQuarterTL, isQuarterTR},
+				[]string{isQuarterBL, isQuarterBR},
+				[]string{isThreeQuarterTL, isThreeQuarterTR},
+				[]string{isThreeQuarterBL, isThreeQuarterBR}),
+		},
+		// mirror-y reverses the rows (top to bottom)
+		mirrorYAction: {
+			position: func(x, y, width, height int) (int, int, int, int) {
+				return x, height - 1 - y, width, height
+			},
+			modes: swapModes(
+				[]string{isTopEdge, isBottomEdge},
+				[]string{isTopLeftBottomRight, isTopRightBottomLeft},
+				[]string{isHalfTLBR, isHalfTRBL},
+				[]string{isQuarterTL, isQuarterBL},
+				[]string{isQuarterTR, isQuarterBR},
+				[]string{isThreeQuarterTL, isThreeQuarterBL},
+				[]string{isThreeQuarterTR, isThreeQuarterBR}),
+		},
+		// rotate90 turns the pattern clockwise
+		rotate90Action: {
+			position: func(x, y, width, height int) (int, int, int, int) {
+				return height - 1 - y, x, height, width
+			},
+			modes: cycleModes(
+				[]string{isTopEdge, isRightEdge, isBottomEdge, isLeftEdge},
+				[]string{isHorizontalLine, isVerticalLine},
+				[]string{isTopLeftBottomRight, isTopRightBottomLeft},
+				[]string{isHalfTLBR, isHalfTRBL},
+				[]string{isQuarterTL, isQuarterTR, isQuarterBR, isQuarterBL},
+				[]string{isThreeQuarterTL, isThreeQuarterTR, isThreeQuarterBR, isThreeQuarterBL}),
+		},
+		rotate180Action: {
+			position: func(x, y, width, height int) (int, int, int, int) {
+				return width - 1 - x, height - 1 - y, width, height
+			},
+			modes: swapModes(
+				[]string{isTopEdge, isBottomEdge},
+				[]string{isLeftEdge, isRightEdge},
+				[]string{isQuarterTL, isQuarterBR},
+				[]string{isQuarterTR, isQuarterBL},
+				[]string{isThreeQuarterTL, isThreeQuarterBR},
+				[]string{isThreeQuarterTR, isThreeQuarterBL}),
+		},
+		// transpose swaps rows and columns (mirrors over the top-left to bottom-right diagonal)
+		transposeAction: {
+			position: func(x, y, width, height int) (int, int, int, int) {
+				return y, x, height, width
+			},
+			modes: swapModes(
+				[]string{isTopEdge, isLeftEdge},
+				[]string{isBottomEdge, isRightEdge},
+				[]string{isHorizontalLine, isVerticalLine},
+				[]string{isQuarterTR, isQuarterBL},
+				[]string{isThreeQuarterTR, isThreeQuarterBL}),
+		},
+	}
+)
+
+// cycleModes maps each mode to the next mode within its cycle.
+func cycleModes(cycles ...[]string) map[string]string {
+	modes := make(map[string]string)
+	for _, cycle := range cycles {
+		for idx, mode := range cycle {
+			modes[mode] = cycle[(idx+1)%len(cycle)]
+		}
+	}
+	return modes
+}
+
+// swapModes maps each pair of modes to each other.
+func swapModes(pairs ...[]string) map[string]string {
+	return cycleModes(pairs...)
+}
+
+// transformMode is the stitch mode after applying the transforms (in order).
+func transformMode(names []string, mode string) string {
+	for _, name := range names {
+		if mapped, ok := transforms[name].modes[mode]; ok {
+			mode = mapped
+		}
+	}
+	return mode
+}
+
+// transformCell is the position of a cell (0-based) within a width by height pattern after applying the transforms (in order).
+func transformCell(names []string, x, y, width, height int) (int, int) {
+	for _, name := range names {
+		x, y, width, height = transforms[name].position(x, y, width, height)
+	}
+	return x, y
+}
+
+// transformSize is the size of a width by height pattern after applying the transforms.
+func transformSize(names []string, width, height int) (int, int) {
+	for _, name := range names {
+		_, _, width, height = transforms[name].position(0, 0, width, height)
+	}
+	return width, height
+}
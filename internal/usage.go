@@ -0,0 +1,166 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	skeinMeters    = 8.0
+	skeinStrands   = 6
+	threadWaste    = 1.2
+	inchesToMeters = 0.0254
+	// a full cross is 2 diagonals on the front and 2 verticals on the back
+	crossTravel = 2*math.Sqrt2 + 2
+	// backstitching covers the length once on the front and twice on the back
+	backstitchTravel = 3.0
+	// a french knot (2 wraps) uses roughly an inch of thread
+	knotInches = 1.0
+)
+
+type (
+	threadUsage struct {
+		color      colorMap
+		stitches   int
+		partial    int
+		knots      int
+		backstitch float64
+		inches     float64
+		meters     float64
+		skeins     int
+	}
+	// ThreadSummary is the machine-readable thread usage of a pattern.
+	ThreadSummary struct {
+		Fabric  string        `json:"fabric"`
+		Strands int           `json:"strands"`
+		Colors  []ThreadColor `json:"colors"`
+	}
+	// ThreadColor is the thread usage of a single color.
+	ThreadColor struct {
+		Color      string  `json:"color"`
+		Floss      string  `json:"floss,omitempty"`
+		Stitches   int     `json:"stitches"`
+		Partial    int     `json:"partial_stitches,omitempty"`
+		Knots      int     `json:"knots,omitempty"`
+		Backstitch float64 `json:"backstitch_cells"`
+		Meters     float64 `json:"meters"`
+		Skeins     int     `json:"skeins"`
+	}
+)
+
+func segmentLength(mode string) float64 {
+	total := 0.0
+	for _, s := range stitchSegments(mode) {
+		total += math.Hypot(s.x2-s.x1, s.y2-s.y1)
+	}
+	return total
+}
+
+func (p Pattern) usage(opts *Option) []threadUsage {
+	tracked := make(map[string]*threadUsage)
+	// blended colors spread their usage over each component floss (a strand each)
+	components := make(map[string][]*threadUsage)
+	track := func(mapped colorMap) *threadUsage {
+		if _, ok := tracked[mapped.output]; !ok {
+			tracked[mapped.output] = &threadUsage{color: mapped}
+		}
+		return tracked[mapped.output]
+	}
+	beads := beadCatalog()
+	for _, mapped := range p.colors {
+		if _, ok := beads[mapped.thread.brand]; ok {
+			continue
+		}
+		if len(mapped.blend) == 0 {
+			components[mapped.output] = []*threadUsage{track(mapped)}
+			continue
+		}
+		for _, floss := range mapped.blend {
+			component := colorMap{input: floss.input, output: floss.resolved, thread: floss.thread, nearest: floss.nearest, distance: floss.distance}
+			components[mapped.output] = append(components[mapped.output], track(component))
+		}
+	}
+	cellInches := 1 / opts.FabricCount()
+	add := func(color string, inches float64, count func(u *threadUsage)) {
+		targets := components[color]
+		for _, usage := range targets {
+			count(usage)
+			usage.inches += inches / float64(len(targets))
+		}
+	}
+	for _, l := range p.lines {
+		length := l.length()
+		add(l.color, length*backstitchTravel*cellInches, func(u *threadUsage) {
+			u.backstitch += length
+		})
+	}
+	for _, e := range p.entries {
+		cells := len(e.cells)
+		switch {
+		case isBackstitch(e.mode):
+			length := float64(cells) * segmentLength(e.mode)
+			add(e.color, length*backstitchTravel*cellInches, func(u *threadUsage) {
+				u.backstitch += length
+			})
+		case e.mode == isKnot:
+			add(e.color, float64(cells)*knotInches, func(u *threadUsage) {
+				u.knots += cells
+			})
+		case e.mode == isBead:
+			continue
+		case isFractional(e.mode):
+			fraction := float64(cells) * stitchFraction(e.mode)
+			add(e.color, fraction*crossTravel*cellInches, func(u *threadUsage) {
+				u.partial += cells
+			})
+		default:
+			add(e.color, float64(cells)*crossTravel*cellInches, func(u *threadUsage) {
+				u.stitches += cells
+			})
+		}
+	}
+	var results []threadUsage
+	for _, usage := range tracked {
+		usage.meters = usage.inches * float64(opts.Strands()) * threadWaste * inchesToMeters
+		usage.skeins = int(math.Ceil(usage.meters / (skeinMeters * skeinStrands)))
+		results = append(results, *usage)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].color.label() < results[j].color.label()
+	})
+	return results
+}
+
+func (u threadUsage) String() string {
+	return fmt.Sprintf("thread: %s => %d stitches, %d partial, %d knots, %.2f cells backstitch, %.2fm, %d skein(s)", u.color.label(), u.stitches, u.partial, u.knots, u.backstitch, u.meters, u.skeins)
+}
+
+func threadHeader(opts *Option) string {
+	return fmt.Sprintf("thread estimate (%s fabric, %d strand(s)):", opts.Fabric(), opts.Strands())
+}
+
+func summary(p Pattern, opts *Option) ([]byte, error) {
+	obj := ThreadSummary{Fabric: opts.Fabric(), Strands: opts.Strands(), Colors: []ThreadColor{}}
+	for _, usage := range p.usage(opts) {
+		color := ThreadColor{
+			Color:      usage.color.input,
+			Stitches:   usage.stitches,
+			Partial:    usage.partial,
+			Knots:      usage.knots,
+			Backstitch: math.Round(usage.backstitch*100) / 100,
+			Meters:     math.Round(usage.meters*100) / 100,
+			Skeins:     usage.skeins,
+		}
+		if usage.color.thread.code != "" {
+			color.Floss = fmt.Sprintf("%s %s", usage.color.thread.brand, usage.color.thread.code)
+		}
+		obj.Colors = append(obj.Colors, color)
+	}
+	b, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
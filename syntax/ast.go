@@ -0,0 +1,357 @@
+package syntax
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	paletteBlock    = "palette"
+	patternBlock    = "pattern"
+	actionBlock     = "action"
+	motifBlock      = "motif"
+	backstitchBlock = "backstitch"
+	offsetBlock     = "offset"
+	repeatBlock     = "repeat"
+	modeBlock       = "mode"
+	pointSeparator  = ","
+	sizeSeparator   = "x"
+	repeatStep      = "step"
+)
+
+type (
+	// Node is any part of a file (blocks, comments and block entries).
+	Node interface {
+		Pos() Pos
+		String() string
+	}
+	// File is a parsed file, blocks and (top-level) comments in the order found.
+	File struct {
+		Nodes []Node
+	}
+	// Comment is a '#' comment (the text includes the '#').
+	Comment struct {
+		Start Pos
+		Text  string
+	}
+	// Block is a named block, the body holds the entries and comments in the order found.
+	Block struct {
+		Start Pos
+		Name  string
+		Open  Pos
+		Close Pos
+		// SingleLine is true for blocks written on one line (name => {entry}).
+		SingleLine bool
+		Body       []Node
+	}
+	// Assignment assigns a color to a symbol within a palette block.
+	Assignment struct {
+		Start    Pos
+		Symbol   string
+		Color    string
+		ColorPos Pos
+	}
+	// Row is a row of symbols within a pattern (or motif) block.
+	Row struct {
+		Start   Pos
+		Symbols string
+	}
+	// Action is a verb (and arguments) within an action block.
+	Action struct {
+		Start Pos
+		Verb  string
+		Args  []string
+	}
+	// Backstitch is a line (through the points) of a symbol within a backstitch block.
+	Backstitch struct {
+		Start  Pos
+		Symbol string
+		Points []*Point
+	}
+	// Point is an X,Y intersection of a backstitch line.
+	Point struct {
+		Start Pos
+		Text  string
+		X     int
+		Y     int
+		// Bad is true when the text is not X,Y (integers).
+		Bad bool
+	}
+	// Size is a WidthxHeight pair (an offset, or the count or step of a repeat).
+	Size struct {
+		Start  Pos
+		Text   string
+		Width  int
+		Height int
+		// Bad is true when the text is not WidthxHeight (integers).
+		Bad bool
+	}
+	// Repeat is the count (and optional step) within a repeat block.
+	Repeat struct {
+		Start Pos
+		Count *Size
+		Step  *Size
+	}
+	// Mode is the stitch mode within a mode block.
+	Mode struct {
+		Start  Pos
+		Stitch string
+	}
+	// Line is any other entry within a block (including a malformed assignment).
+	Line struct {
+		Start Pos
+		Text  string
+	}
+)
+
+// Pos is where the comment starts.
+func (c *Comment) Pos() Pos {
+	return c.Start
+}
+
+func (c *Comment) String() string {
+	return c.Text
+}
+
+// Pos is where the block (name) starts.
+func (b *Block) Pos() Pos {
+	return b.Start
+}
+
+func (b *Block) String() string {
+	return b.Name
+}
+
+// Entries are the entries of the block (without comments).
+func (b *Block) Entries() []Node {
+	var results []Node
+	for _, node := range b.Body {
+		if _, ok := node.(*Comment); !ok {
+			results = append(results, node)
+		}
+	}
+	return results
+}
+
+// Pos is where the assignment (symbol) starts.
+func (a *Assignment) Pos() Pos {
+	return a.Start
+}
+
+func (a *Assignment) String() string {
+	return a.Symbol + " " + arrow + " " + a.Color
+}
+
+// Pos is where the row starts.
+func (r *Row) Pos() Pos {
+	return r.Start
+}
+
+func (r *Row) String() string {
+	return r.Symbols
+}
+
+// Pos is where the action (verb) starts.
+func (a *Action) Pos() Pos {
+	return a.Start
+}
+
+func (a *Action) String() string {
+	return strings.Join(append([]string{a.Verb}, a.Args...), " ")
+}
+
+// Pos is where the backstitch (symbol) starts.
+func (b *Backstitch) Pos() Pos {
+	return b.Start
+}
+
+func (b *Backstitch) String() string {
+	parts := []string{b.Symbol, arrow}
+	for _, point := range b.Points {
+		parts = append(parts, point.String())
+	}
+	return strings.Join(parts, " ")
+}
+
+// Pos is where the point starts.
+func (p *Point) Pos() Pos {
+	return p.Start
+}
+
+func (p *Point) String() string {
+	return p.Text
+}
+
+// Pos is where the size starts.
+func (s *Size) Pos() Pos {
+	return s.Start
+}
+
+func (s *Size) String() string {
+	return s.Text
+}
+
+// Pos is where the repeat (count) starts.
+func (r *Repeat) Pos() Pos {
+	return r.Start
+}
+
+func (r *Repeat) String() string {
+	if r.Step == nil {
+		return r.Count.String()
+	}
+	return strings.Join([]string{r.Count.String(), repeatStep, r.Step.String()}, " ")
+}
+
+// Pos is where the mode starts.
+func (m *Mode) Pos() Pos {
+	return m.Start
+}
+
+func (m *Mode) String() string {
+	return m.Stitch
+}
+
+// Pos is where the line starts.
+func (l *Line) Pos() Pos {
+	return l.Start
+}
+
+func (l *Line) String() string {
+	return l.Text
+}
+
+// Blocks are the blocks of the file (without comments).
+func (f *File) Blocks() []*Block {
+	var results []*Block
+	for _, node := range f.Nodes {
+		if block, ok := node.(*Block); ok {
+			results = append(results, block)
+		}
+	}
+	return results
+}
+
+func isMotif(name string) bool {
+	return name == motifBlock || strings.HasPrefix(name, motifBlock+" ")
+}
+
+// entry is the (typed) entry for text within a block.
+func entry(block string, token Token) Node {
+	switch {
+	case block == paletteBlock:
+		if assignment, ok := toAssignment(token); ok {
+			return assignment
+		}
+	case block == patternBlock || isMotif(block):
+		return &Row{Start: token.Pos, Symbols: token.Text}
+	case block == actionBlock:
+		fields := strings.Fields(token.Text)
+		if len(fields) > 0 {
+			return &Action{Start: token.Pos, Verb: fields[0], Args: fields[1:]}
+		}
+	case block == backstitchBlock:
+		if backstitch, ok := toBackstitch(token); ok {
+			return backstitch
+		}
+	case block == offsetBlock:
+		if fields := toFields(token); len(fields) == 1 {
+			return toSize(fields[0])
+		}
+	case block == repeatBlock:
+		if repeat, ok := toRepeat(token); ok {
+			return repeat
+		}
+	case block == modeBlock:
+		if fields := toFields(token); len(fields) == 1 {
+			return &Mode{Start: fields[0].Pos, Stitch: fields[0].Text}
+		}
+	}
+	return &Line{Start: token.Pos, Text: token.Text}
+}
+
+// toFields are the whitespace separated fields (with positions) of the token text.
+func toFields(token Token) []Token {
+	var results []Token
+	start := -1
+	for idx := 0; idx <= len(token.Text); idx++ {
+		if idx < len(token.Text) && !isSpace(token.Text[idx]) {
+			if start < 0 {
+				start = idx
+			}
+			continue
+		}
+		if start >= 0 {
+			results = append(results, Token{Kind: TokenText, Text: token.Text[start:idx], Pos: Pos{Line: token.Pos.Line, Column: token.Pos.Column + start}})
+			start = -1
+		}
+	}
+	return results
+}
+
+// toPair reads two integers split by the separator (ok is false when they are not).
+func toPair(text, separator string) (int, int, bool) {
+	parts := strings.Split(text, separator)
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	x, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	y, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return x, y, true
+}
+
+func toSize(field Token) *Size {
+	width, height, ok := toPair(field.Text, sizeSeparator)
+	return &Size{Start: field.Pos, Text: field.Text, Width: width, Height: height, Bad: !ok}
+}
+
+// toBackstitch reads symbol => X,Y X,Y..., there must be exactly one symbol and one arrow.
+func toBackstitch(token Token) (*Backstitch, bool) {
+	fields := toFields(token)
+	if len(fields) < 2 || fields[1].Text != arrow || len(fields[0].Text) == 0 {
+		return nil, false
+	}
+	result := &Backstitch{Start: token.Pos, Symbol: fields[0].Text}
+	for _, field := range fields[2:] {
+		if field.Text == arrow {
+			return nil, false
+		}
+		x, y, ok := toPair(field.Text, pointSeparator)
+		result.Points = append(result.Points, &Point{Start: field.Pos, Text: field.Text, X: x, Y: y, Bad: !ok})
+	}
+	return result, true
+}
+
+// toRepeat reads Across[x]Down (step Width[x]Height).
+func toRepeat(token Token) (*Repeat, bool) {
+	fields := toFields(token)
+	switch {
+	case len(fields) == 1:
+		return &Repeat{Start: token.Pos, Count: toSize(fields[0])}, true
+	case len(fields) == 3 && fields[1].Text == repeatStep:
+		return &Repeat{Start: token.Pos, Count: toSize(fields[0]), Step: toSize(fields[2])}, true
+	}
+	return nil, false
+}
+
+// toAssignment reads symbol => color, there must be exactly one symbol and one arrow.
+func toAssignment(token Token) (*Assignment, bool) {
+	at := arrowIndex(token.Text)
+	if at < 0 {
+		return nil, false
+	}
+	symbol := strings.TrimSpace(token.Text[:at])
+	rest := token.Text[at+len(arrow):]
+	value := strings.TrimSpace(rest)
+	if symbol == "" || strings.ContainsAny(symbol, " \t") || value == "" || arrowIndex(value) >= 0 {
+		return nil, false
+	}
+	column := token.Pos.Column + at + len(arrow) + strings.Index(rest, value)
+	return &Assignment{Start: token.Pos, Symbol: symbol, Color: value, ColorPos: Pos{Line: token.Pos.Line, Column: column}}, true
+}
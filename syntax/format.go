@@ -0,0 +1,133 @@
+package syntax
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	indent = "    "
+)
+
+type (
+	// item is a block entry with the comments before (on their own lines) and after (on the same line) it.
+	item struct {
+		leading  []*Comment
+		entry    Node
+		trailing []*Comment
+	}
+	printer struct {
+		lines []string
+	}
+)
+
+func (p *printer) add(line string) {
+	p.lines = append(p.lines, line)
+}
+
+// trail appends comments to the last line.
+func (p *printer) trail(comments ...*Comment) {
+	for _, comment := range comments {
+		p.lines[len(p.lines)-1] += " " + comment.Text
+	}
+}
+
+// end is the (last) line of a node.
+func end(node Node) int {
+	if block, ok := node.(*Block); ok {
+		return block.Close.Line
+	}
+	return node.Pos().Line
+}
+
+// Format prints the file in the canonical layout: blocks holding a palette,
+// rows or backstitch lines are multi-line (indented), any other block with a
+// single entry (and no comments) is single-line, palettes are ordered by
+// symbol, comments are kept (with the entry they precede or follow) and
+// blank lines between blocks are kept (at most one).
+func Format(file *File) []byte {
+	p := &printer{}
+	var prev Node
+	for _, node := range file.Nodes {
+		if prev != nil {
+			if comment, ok := node.(*Comment); ok && comment.Start.Line == end(prev) {
+				p.trail(comment)
+				continue
+			}
+			if node.Pos().Line > end(prev)+1 {
+				p.add("")
+			}
+		}
+		switch n := node.(type) {
+		case *Comment:
+			p.add(n.Text)
+		case *Block:
+			p.block(n)
+		}
+		prev = node
+	}
+	if len(p.lines) == 0 {
+		return nil
+	}
+	return []byte(strings.Join(p.lines, "\n") + "\n")
+}
+
+func isMultiLine(block string) bool {
+	return block == paletteBlock || block == patternBlock || block == backstitchBlock || isMotif(block)
+}
+
+func (p *printer) block(block *Block) {
+	header := block.Name + " " + arrow + " " + lbrace
+	entries := block.Entries()
+	if len(entries) == 1 && len(block.Body) == 1 && !isMultiLine(block.Name) {
+		text := entries[0].String()
+		if !strings.ContainsAny(text, lbrace+rbrace) {
+			p.add(header + text + rbrace)
+			return
+		}
+	}
+	p.add(header)
+	var items []*item
+	var pending []*Comment
+	for _, node := range block.Body {
+		comment, ok := node.(*Comment)
+		if !ok {
+			items = append(items, &item{leading: pending, entry: node})
+			pending = nil
+			continue
+		}
+		switch {
+		case comment.Start.Line == block.Start.Line:
+			p.trail(comment)
+		case len(items) > 0 && pending == nil && comment.Start.Line == items[len(items)-1].entry.Pos().Line:
+			last := items[len(items)-1]
+			last.trailing = append(last.trailing, comment)
+		default:
+			pending = append(pending, comment)
+		}
+	}
+	if block.Name == paletteBlock {
+		sort.SliceStable(items, func(i, j int) bool {
+			return symbol(items[i].entry) < symbol(items[j].entry)
+		})
+	}
+	for _, entry := range items {
+		for _, comment := range entry.leading {
+			p.add(indent + comment.Text)
+		}
+		p.add(indent + entry.entry.String())
+		p.trail(entry.trailing...)
+	}
+	for _, comment := range pending {
+		p.add(indent + comment.Text)
+	}
+	p.add(rbrace)
+}
+
+// symbol orders palette entries (malformed entries by their text).
+func symbol(node Node) string {
+	if assignment, ok := node.(*Assignment); ok {
+		return assignment.Symbol
+	}
+	return node.String()
+}
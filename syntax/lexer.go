@@ -0,0 +1,186 @@
+// Package syntax tokenizes and parses gxs pattern files into an AST.
+package syntax
+
+import (
+	"strings"
+)
+
+// Kind is the kind of a token.
+type Kind int
+
+const (
+	// TokenEOF is the end of the input.
+	TokenEOF Kind = iota
+	// TokenComment is a '#' comment (to the end of the line).
+	TokenComment
+	// TokenName is the name of a block (before the arrow).
+	TokenName
+	// TokenArrow is the '=>' separator.
+	TokenArrow
+	// TokenLBrace opens a block.
+	TokenLBrace
+	// TokenRBrace closes a block.
+	TokenRBrace
+	// TokenText is the content of a line within a block (or anything unexpected outside of a block).
+	TokenText
+)
+
+const (
+	arrow   = "=>"
+	lbrace  = "{"
+	rbrace  = "}"
+	comment = "#"
+)
+
+type (
+	// Pos is a (1-based) line and column (in bytes) within the input.
+	Pos struct {
+		Line   int
+		Column int
+	}
+	// Token is a lexed token with where it starts.
+	Token struct {
+		Kind Kind
+		Text string
+		Pos  Pos
+	}
+	lexer struct {
+		tokens  []Token
+		inBlock bool
+	}
+)
+
+var (
+	kinds = map[Kind]string{
+		TokenEOF:     "EOF",
+		TokenComment: "Comment",
+		TokenName:    "Name",
+		TokenArrow:   "Arrow",
+		TokenLBrace:  "LBrace",
+		TokenRBrace:  "RBrace",
+		TokenText:    "Text",
+	}
+)
+
+func (k Kind) String() string {
+	return kinds[k]
+}
+
+// Lex tokenizes the input, a line starting with '#' is a comment as is a '#'
+// (surrounded by whitespace) ending a line.
+func Lex(src []byte) []Token {
+	l := &lexer{}
+	lines := strings.Split(string(src), "\n")
+	for idx, line := range lines {
+		l.line(idx+1, strings.TrimSuffix(line, "\r"))
+	}
+	l.tokens = append(l.tokens, Token{Kind: TokenEOF, Pos: Pos{Line: len(lines) + 1, Column: 1}})
+	return l.tokens
+}
+
+func (l *lexer) emit(kind Kind, text string, line, column int) {
+	l.tokens = append(l.tokens, Token{Kind: kind, Text: text, Pos: Pos{Line: line, Column: column + 1}})
+}
+
+// emitTrimmed emits the text (without surrounding whitespace) found at the offset of the line.
+func (l *lexer) emitTrimmed(kind Kind, text string, line, offset int) {
+	trimmed := strings.TrimSpace(text)
+	column := offset + strings.Index(text, trimmed)
+	if trimmed == "" {
+		column = offset
+	}
+	l.emit(kind, trimmed, line, column)
+}
+
+func (l *lexer) line(number int, raw string) {
+	code := raw
+	at := commentStart(raw)
+	if at >= 0 {
+		code = raw[:at]
+	}
+	if strings.TrimSpace(code) != "" {
+		if l.inBlock {
+			if strings.TrimSpace(code) == rbrace {
+				l.emitTrimmed(TokenRBrace, code, number, 0)
+				l.inBlock = false
+			} else {
+				l.emitTrimmed(TokenText, code, number, 0)
+			}
+		} else {
+			l.header(number, code)
+		}
+	}
+	if at >= 0 {
+		l.emit(TokenComment, strings.TrimSpace(raw[at:]), number, at)
+	}
+}
+
+// header lexes a line outside of a block: name => { [content }]
+func (l *lexer) header(number int, code string) {
+	at := arrowIndex(code)
+	if at < 0 {
+		l.emitTrimmed(TokenText, code, number, 0)
+		return
+	}
+	l.emitTrimmed(TokenName, code[:at], number, 0)
+	l.emit(TokenArrow, arrow, number, at)
+	offset := at + len(arrow)
+	rest := code[offset:]
+	trimmed := strings.TrimLeft(rest, " \t")
+	if !strings.HasPrefix(trimmed, lbrace) {
+		l.emitTrimmed(TokenText, rest, number, offset)
+		return
+	}
+	offset += len(rest) - len(trimmed)
+	l.emit(TokenLBrace, lbrace, number, offset)
+	offset += len(lbrace)
+	rest = code[offset:]
+	content := strings.TrimRight(rest, " \t")
+	switch {
+	case strings.TrimSpace(content) == "":
+		l.inBlock = true
+	case strings.HasSuffix(content, rbrace):
+		inner := content[:len(content)-len(rbrace)]
+		l.emitTrimmed(TokenText, inner, number, offset)
+		l.emit(TokenRBrace, rbrace, number, offset+len(inner))
+	default:
+		l.emitTrimmed(TokenText, rest, number, offset)
+	}
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t'
+}
+
+// separated is true when the text at the offset (of the given length) is surrounded by whitespace (or the line ends).
+func separated(line string, offset, length int) bool {
+	if offset > 0 && !isSpace(line[offset-1]) {
+		return false
+	}
+	end := offset + length
+	return end >= len(line) || isSpace(line[end])
+}
+
+// commentStart is where a comment starts within the line (-1 when there is none).
+func commentStart(line string) int {
+	trimmed := strings.TrimLeft(line, " \t")
+	if strings.HasPrefix(trimmed, comment) {
+		return len(line) - len(trimmed)
+	}
+	for idx := 0; idx < len(line); idx++ {
+		if strings.HasPrefix(line[idx:], comment) && separated(line, idx, len(comment)) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// arrowIndex is where the first (whitespace separated) arrow is within the line (-1 when there is none).
+func arrowIndex(line string) int {
+	for idx := 0; idx < len(line); idx++ {
+		if strings.HasPrefix(line[idx:], arrow) && separated(line, idx, len(arrow)) {
+			return idx
+		}
+	}
+	return -1
+}
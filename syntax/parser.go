@@ -0,0 +1,131 @@
+package syntax
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	// Error is a syntax error at a position within the input.
+	Error struct {
+		Pos     Pos
+		Message string
+	}
+	parser struct {
+		tokens []Token
+		idx    int
+	}
+)
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Pos.Line, e.Pos.Column, e.Message)
+}
+
+func toError(pos Pos, message string) *Error {
+	return &Error{Pos: pos, Message: message}
+}
+
+// Parse parses the input into a file (the first syntax error found stops parsing).
+func Parse(src []byte) (*File, error) {
+	p := &parser{tokens: Lex(src)}
+	file := &File{}
+	for {
+		token := p.next()
+		switch token.Kind {
+		case TokenEOF:
+			return file, nil
+		case TokenComment:
+			file.Nodes = append(file.Nodes, &Comment{Start: token.Pos, Text: token.Text})
+		case TokenName:
+			block, err := p.block(token)
+			if err != nil {
+				return nil, err
+			}
+			file.Nodes = append(file.Nodes, block)
+		default:
+			return nil, toError(token.Pos, "expected start of block")
+		}
+	}
+}
+
+func (p *parser) next() Token {
+	token := p.tokens[p.idx]
+	if token.Kind != TokenEOF {
+		p.idx++
+	}
+	return token
+}
+
+func (p *parser) peek() Token {
+	return p.tokens[p.idx]
+}
+
+// sameLine is the next token when it is on the line (of the given token).
+func (p *parser) sameLine(token Token) (Token, bool) {
+	next := p.peek()
+	if next.Kind == TokenEOF || next.Kind == TokenComment || next.Pos.Line != token.Pos.Line {
+		return Token{}, false
+	}
+	return p.next(), true
+}
+
+func (p *parser) block(name Token) (*Block, *Error) {
+	block := &Block{Start: name.Pos, Name: name.Text}
+	// the lexer always follows a name with an arrow
+	p.next()
+	open, ok := p.sameLine(name)
+	if !ok || open.Kind != TokenLBrace {
+		return nil, toError(name.Pos, "expected start of block")
+	}
+	if block.Name == "" {
+		return nil, toError(name.Pos, "invalid start block")
+	}
+	block.Open = open.Pos
+	if content, ok := p.sameLine(open); ok {
+		return p.singleLine(block, content)
+	}
+	for {
+		token := p.next()
+		switch token.Kind {
+		case TokenEOF:
+			return nil, toError(name.Pos, "unclosed block at block: 1")
+		case TokenComment:
+			block.Body = append(block.Body, &Comment{Start: token.Pos, Text: token.Text})
+		case TokenRBrace:
+			if len(block.Entries()) == 0 {
+				return nil, toError(name.Pos, "empty block found")
+			}
+			block.Close = token.Pos
+			return block, nil
+		default:
+			block.Body = append(block.Body, entry(block.Name, token))
+		}
+	}
+}
+
+// singleLine reads name => {entry} where the entry can not start another block.
+func (p *parser) singleLine(block *Block, content Token) (*Block, *Error) {
+	closed, ok := p.sameLine(content)
+	if !ok || closed.Kind != TokenRBrace {
+		if arrowIndex(content.Text) >= 0 {
+			return nil, toError(block.Start, "invalid start block")
+		}
+		return nil, toError(block.Start, "expected start of block")
+	}
+	if isHeader(content.Text) {
+		return nil, toError(block.Start, "single-line start of block invalid")
+	}
+	if content.Text == "" {
+		return nil, toError(block.Start, "empty block found")
+	}
+	block.SingleLine = true
+	block.Close = closed.Pos
+	block.Body = []Node{entry(block.Name, content)}
+	return block, nil
+}
+
+// isHeader is true when the text starts a block (name => {).
+func isHeader(text string) bool {
+	at := arrowIndex(text)
+	return at > 0 && strings.HasPrefix(strings.TrimSpace(text[at+len(arrow):]), lbrace)
+}
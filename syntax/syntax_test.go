@@ -0,0 +1,147 @@
+package syntax_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"voidedtech.com/gxs/syntax"
+)
+
+func TestLex(t *testing.T) {
+	var tokens []string
+	for _, token := range syntax.Lex([]byte("# top\npalette => {\n  x => #333333 # dark\n}\nmode => {xstitch}")) {
+		tokens = append(tokens, fmt.Sprintf("%d:%d %s %q", token.Pos.Line, token.Pos.Column, token.Kind, token.Text))
+	}
+	expect := []string{
+		`1:1 Comment "# top"`,
+		`2:1 Name "palette"`,
+		`2:9 Arrow "=>"`,
+		`2:12 LBrace "{"`,
+		`3:3 Text "x => #333333"`,
+		`3:16 Comment "# dark"`,
+		`4:1 RBrace "}"`,
+		`5:1 Name "mode"`,
+		`5:6 Arrow "=>"`,
+		`5:9 LBrace "{"`,
+		`5:10 Text "xstitch"`,
+		`5:17 RBrace "}"`,
+		`6:1 EOF ""`,
+	}
+	if strings.Join(tokens, "\n") != strings.Join(expect, "\n") {
+		t.Errorf("invalid tokens: %v", tokens)
+	}
+}
+
+func TestParse(t *testing.T) {
+	file, err := syntax.Parse([]byte(`# colors
+palette => {
+    = => red   # symbols can be '='
+    > => blue+green
+    x => y => z
+}
+pattern => {
+    # rows can hold '=>'
+    =>=
+}
+action => {stamp dot 1x2 palette}
+mode => {xstitch}`))
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	if len(file.Nodes) != 5 || len(file.Blocks()) != 4 {
+		t.Fatalf("invalid nodes: %v", file.Nodes)
+	}
+	if comment, ok := file.Nodes[0].(*syntax.Comment); !ok || comment.Text != "# colors" {
+		t.Error("top-level comment not kept")
+	}
+	palette := file.Blocks()[0]
+	if palette.Name != "palette" || len(palette.Body) != 4 || len(palette.Entries()) != 3 {
+		t.Fatalf("invalid palette: %v", palette.Body)
+	}
+	assignment, ok := palette.Entries()[0].(*syntax.Assignment)
+	if !ok || assignment.Symbol != "=" || assignment.Color != "red" || assignment.ColorPos != (syntax.Pos{Line: 3, Column: 10}) {
+		t.Errorf("invalid assignment: %v", palette.Entries()[0])
+	}
+	if comment, ok := palette.Body[1].(*syntax.Comment); !ok || comment.Text != "# symbols can be '='" || comment.Pos().Line != 3 {
+		t.Error("trailing comment not kept")
+	}
+	if blend, ok := palette.Entries()[1].(*syntax.Assignment); !ok || blend.String() != "> => blue+green" {
+		t.Error("invalid blend assignment")
+	}
+	// ambiguous assignments are kept (as lines) for the consumer to report
+	if line, ok := palette.Entries()[2].(*syntax.Line); !ok || line.Text != "x => y => z" {
+		t.Errorf("ambiguous assignment should be a line: %v", palette.Entries()[2])
+	}
+	pattern := file.Blocks()[1]
+	if row, ok := pattern.Entries()[0].(*syntax.Row); !ok || row.Symbols != "=>=" || row.Pos() != (syntax.Pos{Line: 9, Column: 5}) {
+		t.Errorf("invalid row: %v", pattern.Entries())
+	}
+	action := file.Blocks()[2]
+	step, ok := action.Entries()[0].(*syntax.Action)
+	if !ok || !action.SingleLine || step.Verb != "stamp" || strings.Join(step.Args, ",") != "dot,1x2,palette" || step.Pos().Column != 12 {
+		t.Errorf("invalid action: %v", action.Entries())
+	}
+	if mode, ok := file.Blocks()[3].Entries()[0].(*syntax.Mode); !ok || mode.Stitch != "xstitch" || mode.Pos() != (syntax.Pos{Line: 12, Column: 10}) {
+		t.Error("invalid mode")
+	}
+}
+
+func TestParseValues(t *testing.T) {
+	file, err := syntax.Parse([]byte(`backstitch => {
+    x  =>  1,1 a,b -2,3
+    x 1,1 2,2
+}
+offset => {1x-2}
+repeat => {3x2 step 4x0}
+repeat => {3x2 step}
+mode => {half tlbr}`))
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	blocks := file.Blocks()
+	line, ok := blocks[0].Entries()[0].(*syntax.Backstitch)
+	if !ok || line.Symbol != "x" || len(line.Points) != 3 || line.String() != "x => 1,1 a,b -2,3" {
+		t.Fatalf("invalid backstitch: %v", blocks[0].Entries()[0])
+	}
+	if point := line.Points[0]; point.Bad || point.X != 1 || point.Y != 1 || point.Pos() != (syntax.Pos{Line: 2, Column: 12}) {
+		t.Errorf("invalid point: %v", point)
+	}
+	if !line.Points[1].Bad || line.Points[2].Bad || line.Points[2].X != -2 {
+		t.Error("invalid points")
+	}
+	if _, ok := blocks[0].Entries()[1].(*syntax.Line); !ok {
+		t.Error("a backstitch needs an arrow")
+	}
+	if size, ok := blocks[1].Entries()[0].(*syntax.Size); !ok || size.Bad || size.Width != 1 || size.Height != -2 || size.Pos() != (syntax.Pos{Line: 5, Column: 12}) {
+		t.Errorf("invalid offset: %v", blocks[1].Entries()[0])
+	}
+	repeat, ok := blocks[2].Entries()[0].(*syntax.Repeat)
+	if !ok || repeat.Count.Width != 3 || repeat.Count.Height != 2 || repeat.Step == nil || repeat.Step.Width != 4 || repeat.String() != "3x2 step 4x0" {
+		t.Errorf("invalid repeat: %v", blocks[2].Entries()[0])
+	}
+	if _, ok := blocks[3].Entries()[0].(*syntax.Line); !ok {
+		t.Error("a repeat step needs a size")
+	}
+	if _, ok := blocks[4].Entries()[0].(*syntax.Line); !ok {
+		t.Error("a mode is a single value")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for input, expect := range map[string]string{
+		"myblock":                           "1:1: expected start of block",
+		"\n  myblock => {":                  "2:3: unclosed block at block: 1",
+		"myblock => {\n}":                   "1:1: empty block found",
+		"myblock => {\n# only a comment\n}": "1:1: empty block found",
+		"myblock => { => {":                 "1:1: invalid start block",
+		"action => {action => {commit}":     "1:1: single-line start of block invalid",
+		"x => red":                          "1:1: expected start of block",
+		"}":                                 "1:1: expected start of block",
+	} {
+		_, err := syntax.Parse([]byte(input))
+		if err == nil || err.Error() != expect {
+			t.Errorf("invalid error for %q: %v", input, err)
+		}
+	}
+}